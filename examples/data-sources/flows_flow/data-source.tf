@@ -0,0 +1,14 @@
+# Look up a flow by its ID...
+data "flows_flow" "by_id" {
+  id = "flow-id"
+}
+
+# ...or by its project and name.
+data "flows_flow" "by_name" {
+  project_id = "your-project-id"
+  name       = "Shared Notifications"
+}
+
+output "my_entity_id" {
+  value = data.flows_flow.by_name.blocks["my_entity"].id
+}
@@ -0,0 +1,8 @@
+data "flows_flow_block" "webhook" {
+  flow_id = flows_flow.example.id
+  name    = "incoming_webhook"
+}
+
+output "webhook_url" {
+  value = data.flows_flow_block.webhook.public_url
+}
@@ -0,0 +1,12 @@
+data "flows_flow_revisions" "example" {
+  flow_id = flows_flow.example.id
+}
+
+locals {
+  revisions = data.flows_flow_revisions.example.revisions
+}
+
+# The revision before the current one, or null if the flow has only been applied once.
+output "previous_revision" {
+  value = length(local.revisions) >= 2 ? local.revisions[length(local.revisions) - 2].revision : null
+}
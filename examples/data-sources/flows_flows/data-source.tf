@@ -0,0 +1,8 @@
+data "flows_flows" "example" {
+  project_id  = "your-project-id"
+  name_prefix = "team-a-"
+}
+
+output "flow_ids" {
+  value = [for flow in data.flows_flows.example.flows : flow.id]
+}
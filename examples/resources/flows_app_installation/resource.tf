@@ -11,6 +11,28 @@ resource "flows_app_installation" "example" {
     example: "\"example-value\""
   }
 
+  # Typed values, encoded as JSON by the provider.
+  config = {
+    retries  = 3
+    channels = ["#alerts", "#ops"]
+  }
+
+  # Hidden from the plan output, but stored in the state.
+  sensitive_config_fields = {
+    webhook_secret: "\"webhook-secret\""
+  }
+
+  # Never stored in the state, sent again whenever the version changes.
+  config_fields_wo = {
+    api_key: "\"api-key\""
+  }
+  config_fields_wo_version = 1
+
+  # Resolved from project secrets by the Flows API, so rotating them takes effect immediately.
+  config_field_secrets = {
+    signing_key = flows_secret.signing_key.key
+  }
+
   style_override = {
     color: "#ff0000"
   }
@@ -18,3 +40,18 @@ resource "flows_app_installation" "example" {
   confirm        = true
   wait_for_ready = true
 }
+
+# Apps which take long to provision can be given more time to become ready.
+resource "flows_app_installation" "slow" {
+  project_id = "your-project-id"
+  name       = "Slow Installation"
+
+  app = {
+    version_id = "app-version-id"
+  }
+
+  timeouts = {
+    create = "20m"
+    update = "20m"
+  }
+}
@@ -19,3 +19,12 @@ resource "flows_app_installation_config_field" "webhook_url" {
   key                 = "webhook_url"
   value               = "https://example.com/webhook"
 }
+
+# Credentials can be kept out of the state with a write-only value,
+# bumping the version whenever the value changes.
+resource "flows_app_installation_config_field" "api_token" {
+  app_installation_id = flows_app_installation.example.id
+  key                 = "api_token"
+  value_wo            = var.api_token
+  value_wo_version    = 1
+}
@@ -6,4 +6,87 @@ resource "flows_flow" "example" {
   app_installation_mapping = {
     my_app = "app-installation-id"
   }
+
+  # Data tables and secrets referenced in the definition by these keys, e.g. as "{{ data_table.customers }}".
+  data_table_mapping = {
+    customers = flows_data_table.customers.id
+  }
+  secret_mapping = {
+    api_token = flows_secret.api_token.id
+  }
+
+  # Confirm the blocks' entities and wait for them to be ready after every apply.
+  confirm_blocks = true
+  wait_for_ready = true
+}
+
+# Alternatively, the blocks can be defined in HCL.
+resource "flows_flow" "hcl" {
+  project_id = "your-project-id"
+  name       = "My HCL Flow"
+
+  block = [
+    {
+      name = "on_schedule"
+      type = "schedule"
+      app  = "core"
+      config = jsonencode({
+        cron = "0 * * * *"
+      })
+      connections = [
+        { target = "notify" },
+      ]
+    },
+    {
+      name = "notify"
+      type = "sendMessage"
+      app  = "slack"
+      config = jsonencode({
+        channel = "#alerts"
+      })
+      position = { x = 300, y = 0 }
+    },
+  ]
+
+  app_installation_mapping = {
+    slack = "app-installation-id"
+  }
+}
+
+# The same definition can be reused across environments with variables.
+resource "flows_flow" "templated" {
+  project_id = "your-project-id"
+  name       = "My Templated Flow"
+  definition = file("${path.module}/flow.yaml") # uses e.g. {{ var.channel }}
+
+  definition_variables = {
+    channel = "#alerts-production"
+  }
+}
+
+# Big flows can be split into multiple YAML files, merged into one definition.
+resource "flows_flow" "split" {
+  project_id     = "your-project-id"
+  name           = "My Split Flow"
+  definition_dir = "${path.module}/flow"
+}
+
+# Flows edited in the UI are not reverted silently, but block the plan instead.
+resource "flows_flow" "locked" {
+  project_id = "your-project-id"
+  name       = "Locked Flow"
+  definition = file("${path.module}/flow.yaml")
+
+  drift_policy    = "fail"
+  mark_as_managed = true
+}
+
+# Temporarily roll a misbehaving flow back to an earlier revision,
+# see the flows_flow_revisions data source.
+resource "flows_flow" "rolled_back" {
+  project_id = "your-project-id"
+  name       = "Rolled Back Flow"
+  definition = file("${path.module}/flow.yaml")
+
+  rollback_to_revision = 3
 }
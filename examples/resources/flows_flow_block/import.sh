@@ -0,0 +1 @@
+terraform import flows_flow_block.example $FLOW_ID/$BLOCK_NAME
@@ -0,0 +1,33 @@
+# The flow only manages the blocks in its own definition,
+# so that other teams can add their blocks to it.
+resource "flows_flow" "shared" {
+  project_id     = "your-project-id"
+  name           = "Shared Flow"
+  definition     = file("${path.module}/flow.yaml")
+  managed_blocks = "defined"
+}
+
+resource "flows_flow_block" "notify_team" {
+  flow_id = flows_flow.shared.id
+  name    = "notify_team"
+  type    = "sendMessage"
+  app     = "slack"
+
+  app_installation_id = "app-installation-id"
+  config = jsonencode({
+    channel = "#my-team"
+    message = "A new event arrived"
+  })
+}
+
+# Connect a block of the shared flow to our block.
+resource "flows_flow_block" "forward" {
+  flow_id = flows_flow.shared.id
+  name    = "forward_to_team"
+  type    = "forward"
+  app     = "core"
+
+  connections = [
+    { target = flows_flow_block.notify_team.name },
+  ]
+}
@@ -0,0 +1,18 @@
+resource "flows_flow_promotion" "to_production" {
+  source_flow_id = flows_flow.staging.id
+  target_flow_id = flows_flow.production.id
+
+  app_installation_id_mapping = {
+    (flows_app_installation.staging_slack.id) = flows_app_installation.production_slack.id
+  }
+  data_table_id_mapping = {
+    (flows_data_table.staging_customers.id) = flows_data_table.production_customers.id
+  }
+  secret_id_mapping = {
+    (flows_secret.staging_token.id) = flows_secret.production_token.id
+  }
+}
+
+output "promotion_plan" {
+  value = flows_flow_promotion.to_production.readable_plan
+}
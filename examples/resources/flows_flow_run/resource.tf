@@ -0,0 +1,19 @@
+resource "flows_flow_run" "smoke_test" {
+  flow_id    = flows_flow.example.id
+  block_name = "on_webhook"
+  input = jsonencode({
+    message = "Hello from Terraform"
+  })
+
+  triggers = {
+    definition = flows_flow.example.definition_sha256
+  }
+
+  timeouts = {
+    create = "10m"
+  }
+}
+
+output "smoke_test_output" {
+  value = jsondecode(flows_flow_run.smoke_test.output)
+}
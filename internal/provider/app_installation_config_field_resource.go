@@ -3,10 +3,16 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,6 +31,8 @@ type AppInstallationConfigFieldResourceModel struct {
 	AppInstallationID types.String `tfsdk:"app_installation_id"`
 	Key               types.String `tfsdk:"key"`
 	Value             types.String `tfsdk:"value"`
+	ValueWO           types.String `tfsdk:"value_wo"`
+	ValueWOVersion    types.Int64  `tfsdk:"value_wo_version"`
 }
 
 func (r *AppInstallationConfigFieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +60,25 @@ func (r *AppInstallationConfigFieldResource) Schema(ctx context.Context, req res
 			"value": schema.StringAttribute{
 				MarkdownDescription: `The configuration field value. If "null", the configuration field will be removed.`,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: `The configuration field value, never stored in the state, for credentials like API keys. It is only sent when the field is created and when "value_wo_version" changes.`,
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("value_wo_version")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: `Version of "value_wo". Change it to send a new value of "value_wo".`,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
+				},
 			},
 		},
 	}
@@ -71,6 +98,9 @@ func (r *AppInstallationConfigFieldResource) Create(ctx context.Context, req res
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	value, diags := r.configuredValue(ctx, req.Config, data)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -78,7 +108,7 @@ func (r *AppInstallationConfigFieldResource) Create(ctx context.Context, req res
 	_, err := CallFlowsAPI[UpdateAppInstallationConfigRequest, UpdateAppInstallationConfigResponse](*r.providerData, updateAppInstallationConfigPath, UpdateAppInstallationConfigRequest{
 		ID: data.AppInstallationID.ValueString(),
 		ConfigFields: map[string]*string{
-			data.Key.ValueString(): data.Value.ValueStringPointer(),
+			data.Key.ValueString(): value.ValueStringPointer(),
 		},
 	})
 	if err != nil {
@@ -86,9 +116,21 @@ func (r *AppInstallationConfigFieldResource) Create(ctx context.Context, req res
 		return
 	}
 
+	data.ValueWO = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// configuredValue returns the value to send for the field, which is only available in the configuration when write-only.
+func (r *AppInstallationConfigFieldResource) configuredValue(ctx context.Context, config tfsdk.Config, data AppInstallationConfigFieldResourceModel) (types.String, diag.Diagnostics) {
+	if !data.Value.IsNull() {
+		return data.Value, nil
+	}
+
+	var valueWO types.String
+	diags := config.GetAttribute(ctx, path.Root("value_wo"), &valueWO)
+	return valueWO, diags
+}
+
 type GetAppInstallationConfigFieldRequest struct {
 	ID  string `json:"id"`
 	Key string `json:"key"`
@@ -122,7 +164,10 @@ func (r *AppInstallationConfigFieldResource) Read(ctx context.Context, req resou
 		return
 	}
 
-	data.Value = types.StringPointerValue(configFieldResp.Value)
+	// The value of a write-only field must not end up in the state.
+	if data.ValueWOVersion.IsNull() {
+		data.Value = types.StringPointerValue(configFieldResp.Value)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -139,11 +184,17 @@ func (r *AppInstallationConfigFieldResource) Update(ctx context.Context, req res
 		return
 	}
 
-	if !data.Value.Equal(config.Value) {
+	if !data.Value.Equal(config.Value) || !data.ValueWOVersion.Equal(config.ValueWOVersion) {
+		value, diags := r.configuredValue(ctx, req.Config, config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
 		_, err := CallFlowsAPI[UpdateAppInstallationConfigRequest, UpdateAppInstallationConfigResponse](*r.providerData, updateAppInstallationConfigPath, UpdateAppInstallationConfigRequest{
 			ID: data.AppInstallationID.ValueString(),
 			ConfigFields: map[string]*string{
-				config.Key.ValueString(): config.Value.ValueStringPointer(),
+				config.Key.ValueString(): value.ValueStringPointer(),
 			},
 		})
 		if err != nil {
@@ -152,6 +203,7 @@ func (r *AppInstallationConfigFieldResource) Update(ctx context.Context, req res
 		}
 
 		data.Value = config.Value
+		data.ValueWOVersion = config.ValueWOVersion
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	}
 }
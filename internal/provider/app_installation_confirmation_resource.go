@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -24,9 +25,10 @@ func NewAppInstallationConfirmationResource() resource.Resource {
 }
 
 type AppInstallationConfirmationResourceModel struct {
-	AppInstallationID types.String `tfsdk:"app_installation_id"`
-	Status            types.String `tfsdk:"status"`
-	WaitForReady      types.Bool   `tfsdk:"wait_for_ready"`
+	AppInstallationID types.String   `tfsdk:"app_installation_id"`
+	Status            types.String   `tfsdk:"status"`
+	WaitForReady      types.Bool     `tfsdk:"wait_for_ready"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AppInstallationConfirmationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +57,10 @@ This is useful for creating app installations using the "app_installation" resou
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: `How long to wait for the app installation to be ready when "wait_for_ready" is true. Defaults to 5 minutes.`,
+			}),
 		},
 	}
 }
@@ -77,6 +83,12 @@ func (r *AppInstallationConfirmationResource) Create(ctx context.Context, req re
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	appInstallationID := data.AppInstallationID.ValueString()
 
 	statusResp, err := CallFlowsAPI[GetAppInstallationStatusRequest, GetAppInstallationStatusResponse](*r.providerData, getAppInstallationStatusPath, GetAppInstallationStatusRequest{
@@ -123,6 +135,7 @@ func (r *AppInstallationConfirmationResource) Create(ctx context.Context, req re
 			ctx,
 			*r.providerData,
 			appInstallationID,
+			createTimeout,
 			&resp.Diagnostics,
 		)
 		if status != nil {
@@ -160,11 +173,25 @@ func (r *AppInstallationConfirmationResource) Read(ctx context.Context, req reso
 }
 
 func (r *AppInstallationConfirmationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// This resource doesn't support updates
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		`The "app_installation_confirmation" resource does not support updates. Please destroy and recreate.`,
-	)
+	var data, plan AppInstallationConfirmationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// This resource doesn't support updates, apart from its timeouts
+	if !data.WaitForReady.Equal(plan.WaitForReady) {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			`The "app_installation_confirmation" resource does not support updates. Please destroy and recreate.`,
+		)
+		return
+	}
+
+	data.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AppInstallationConfirmationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -3,8 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -14,8 +19,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -37,11 +42,6 @@ const (
 	confirmAppInstallationPath        = "/provider/apps/confirm_installation"
 )
 
-const (
-	maxPollRetries    = 60
-	pollRetryInterval = 5 * time.Second
-)
-
 type AppInstallationResource struct {
 	providerData *FlowsProviderConfiguredData
 }
@@ -51,14 +51,20 @@ func NewAppInstallationResource() resource.Resource {
 }
 
 type AppInstallationResourceModel struct {
-	ProjectID     types.String `tfsdk:"project_id"`
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	App           types.Object `tfsdk:"app"`
-	ConfigFields  types.Map    `tfsdk:"config_fields"`
-	Confirm       types.Bool   `tfsdk:"confirm"`
-	WaitForReady  types.Bool   `tfsdk:"wait_for_ready"`
-	StyleOverride types.Object `tfsdk:"style_override"`
+	ProjectID             types.String   `tfsdk:"project_id"`
+	ID                    types.String   `tfsdk:"id"`
+	Name                  types.String   `tfsdk:"name"`
+	App                   types.Object   `tfsdk:"app"`
+	ConfigFields          types.Map      `tfsdk:"config_fields"`
+	SensitiveConfigFields types.Map      `tfsdk:"sensitive_config_fields"`
+	ConfigFieldsWO        types.Map      `tfsdk:"config_fields_wo"`
+	ConfigFieldsWOVersion types.Int64    `tfsdk:"config_fields_wo_version"`
+	ConfigFieldSecrets    types.Map      `tfsdk:"config_field_secrets"`
+	Config                types.Dynamic  `tfsdk:"config"`
+	Confirm               types.Bool     `tfsdk:"confirm"`
+	WaitForReady          types.Bool     `tfsdk:"wait_for_ready"`
+	StyleOverride         types.Object   `tfsdk:"style_override"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
 type AppInstallationApp struct {
@@ -73,6 +79,7 @@ func (r *AppInstallationResource) Metadata(ctx context.Context, req resource.Met
 func (r *AppInstallationResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		waitForReadyValidator{},
+		configFieldsValidator{},
 	}
 }
 
@@ -125,6 +132,38 @@ func (r *AppInstallationResource) Schema(ctx context.Context, req resource.Schem
 					),
 				),
 			},
+			"sensitive_config_fields": schema.MapAttribute{
+				Description: "Configuration settings for the app installation holding credentials, like API keys. They are stored in the state, but hidden from the plan output.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"config_fields_wo": schema.MapAttribute{
+				Description: `Configuration settings for the app installation which are never stored in the state. They are only sent when the app installation is created and when "config_fields_wo_version" changes.`,
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("config_fields_wo_version")),
+				},
+			},
+			"config_fields_wo_version": schema.Int64Attribute{
+				Description: `Version of "config_fields_wo". Change it to send new values of "config_fields_wo".`,
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("config_fields_wo")),
+				},
+			},
+			"config": schema.DynamicAttribute{
+				MarkdownDescription: "Configuration settings for the app installation as an object of arbitrary values, e.g. numbers, booleans, lists or objects, which are encoded as JSON, unlike `config_fields` which takes JSON strings.",
+				Optional:            true,
+			},
+			"config_field_secrets": schema.MapAttribute{
+				MarkdownDescription: "Configuration settings for the app installation bound to project secrets, keyed by config field, with the keys of the secrets as values, e.g. of `flows_secret` resources. The secret values are resolved by the Flows API, so rotating a secret doesn't require applying the app installation again. Removing a field from the map unbinds it from its secret.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"confirm": schema.BoolAttribute{
 				Description: "Whether to automatically confirm the app installation in case it is in a draft mode.",
 				Optional:    true,
@@ -150,6 +189,14 @@ func (r *AppInstallationResource) Schema(ctx context.Context, req resource.Schem
 				},
 				Optional: true,
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: `How long to wait for the app installation to be ready when "wait_for_ready" is true. Defaults to 5 minutes.`,
+				Update:            true,
+				UpdateDescription: `How long to wait for the app installation to be ready when "wait_for_ready" is true. Defaults to 5 minutes.`,
+				Delete:            true,
+				DeleteDescription: "How long to wait for the app installation to be drained after deleting it. Defaults to 5 minutes.",
+			}),
 		},
 	}
 }
@@ -203,6 +250,8 @@ type CreateAppInstallationResponse struct {
 type UpdateAppInstallationConfigRequest struct {
 	ID           string             `json:"id"`
 	ConfigFields map[string]*string `json:"configFields"`
+	// ConfigFieldSecrets binds config fields to the project secrets with the given keys, a nil key unbinds the field.
+	ConfigFieldSecrets map[string]*string `json:"configFieldSecrets,omitempty"`
 }
 
 type UpdateAppInstallationConfigResponse struct {
@@ -222,6 +271,12 @@ func (r *AppInstallationResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createAppInstallationRes, err := CallFlowsAPI[CreateAppInstallationRequest, CreateAppInstallationResponse](*r.providerData, createAppInstallationPath, CreateAppInstallationRequest{
 		ProjectID: data.ProjectID.ValueString(),
 		Name:      data.Name.ValueString(),
@@ -238,21 +293,27 @@ func (r *AppInstallationResource) Create(ctx context.Context, req resource.Creat
 
 	data.ID = types.StringValue(createAppInstallationRes.ID)
 
+	// Write-only values are only available in the configuration.
+	var configFieldsWO types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config_fields_wo"), &configFieldsWO)...)
+	data.ConfigFieldsWO = types.MapNull(types.StringType)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if len(data.ConfigFields.Elements()) != 0 {
+	configFields := configFieldsRequest(data.ConfigFields, data.SensitiveConfigFields, configFieldsWO)
+	resp.Diagnostics.Append(addDynamicConfigFields(ctx, configFields, data.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configFieldSecrets := configFieldSecretsRequest(types.MapNull(types.StringType), data.ConfigFieldSecrets)
+	if len(configFields) != 0 || len(configFieldSecrets) != 0 {
 		_, err := CallFlowsAPI[UpdateAppInstallationConfigRequest, UpdateAppInstallationConfigResponse](*r.providerData, updateAppInstallationConfigPath, UpdateAppInstallationConfigRequest{
-			ID: createAppInstallationRes.ID,
-			ConfigFields: func() map[string]*string {
-				m := make(map[string]*string)
-
-				for k, v := range data.ConfigFields.Elements() {
-					nv := v.(types.String).ValueString()
-					m[k] = &nv
-				}
-
-				return m
-			}(),
+			ID:                 createAppInstallationRes.ID,
+			ConfigFields:       configFields,
+			ConfigFieldSecrets: configFieldSecrets,
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", "Unable to update app installation config, got error: "+err.Error())
@@ -282,6 +343,7 @@ func (r *AppInstallationResource) Create(ctx context.Context, req resource.Creat
 			ctx,
 			*r.providerData,
 			createAppInstallationRes.ID,
+			createTimeout,
 			&resp.Diagnostics,
 		)
 	}
@@ -292,11 +354,14 @@ type GetAppInstallationRequest struct {
 }
 
 type GetAppInstallationResponse struct {
+	ProjectID     string                        `json:"projectId"`
 	Name          string                        `json:"name"`
 	Status        string                        `json:"status"`
 	App           AppInstallationApp            `json:"app"`
 	StyleOverride *AppInstallationStyleOverride `json:"styleOverride"`
 	ConfigFields  map[string]string             `json:"configFields"`
+	// ConfigFieldSecrets holds the keys of the project secrets bound to config fields.
+	ConfigFieldSecrets map[string]string `json:"configFieldSecrets"`
 }
 
 func (r *AppInstallationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -360,21 +425,16 @@ func (r *AppInstallationResource) Read(ctx context.Context, req resource.ReadReq
 		)
 	}
 
-	data.ConfigFields = func() types.Map {
-		m := make(map[string]attr.Value)
+	data.ConfigFields = refreshConfigFields(data.ConfigFields, appInstallation.ConfigFields)
+	data.SensitiveConfigFields = refreshConfigFields(data.SensitiveConfigFields, appInstallation.ConfigFields)
+	data.ConfigFieldSecrets = refreshConfigFields(data.ConfigFieldSecrets, appInstallation.ConfigFieldSecrets)
 
-		// Only include fields that were originally in the plan/state.
-		for k, v := range appInstallation.ConfigFields {
-			_, ok := data.ConfigFields.Elements()[k]
-			if !ok {
-				continue
-			}
-
-			m[k] = types.StringValue(v)
-		}
-
-		return types.MapValueMust(types.StringType, m)
-	}()
+	config, diags := refreshDynamicConfigFields(ctx, data.Config, appInstallation.ConfigFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Config = config
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -411,6 +471,12 @@ func (r *AppInstallationResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	updateTimeout, diags := config.Timeouts.Update(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var checksChanged bool
 
 	if !data.Confirm.Equal(config.Confirm) {
@@ -423,6 +489,11 @@ func (r *AppInstallationResource) Update(ctx context.Context, req resource.Updat
 		checksChanged = true
 	}
 
+	if !data.Timeouts.Equal(config.Timeouts) {
+		data.Timeouts = config.Timeouts
+		checksChanged = true
+	}
+
 	if checksChanged {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	}
@@ -466,25 +537,29 @@ func (r *AppInstallationResource) Update(ctx context.Context, req resource.Updat
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	}
 
-	if !data.ConfigFields.Equal(config.ConfigFields) {
-		if len(config.ConfigFields.Elements()) != 0 {
+	configFieldsWOChanged := !data.ConfigFieldsWOVersion.Equal(config.ConfigFieldsWOVersion)
+
+	if !data.ConfigFields.Equal(config.ConfigFields) || !data.SensitiveConfigFields.Equal(config.SensitiveConfigFields) || !data.ConfigFieldSecrets.Equal(config.ConfigFieldSecrets) || !data.Config.Equal(config.Config) || configFieldsWOChanged {
+		// Write-only values are only available in the configuration, and only sent when their version changes.
+		configFieldsWO := types.MapNull(types.StringType)
+		if configFieldsWOChanged {
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config_fields_wo"), &configFieldsWO)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		configFields := configFieldsRequest(config.ConfigFields, config.SensitiveConfigFields, configFieldsWO)
+		resp.Diagnostics.Append(addDynamicConfigFields(ctx, configFields, config.Config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		configFieldSecrets := configFieldSecretsRequest(data.ConfigFieldSecrets, config.ConfigFieldSecrets)
+		if len(configFields) != 0 || len(configFieldSecrets) != 0 {
 			reqResp, err := CallFlowsAPI[UpdateAppInstallationConfigRequest, UpdateAppInstallationConfigResponse](*r.providerData, updateAppInstallationConfigPath, UpdateAppInstallationConfigRequest{
-				ID: data.ID.ValueString(),
-				ConfigFields: func() map[string]*string {
-					m := make(map[string]*string)
-
-					for k, v := range config.ConfigFields.Elements() {
-						if v.IsNull() {
-							m[k] = nil
-							continue
-						}
-
-						nv := v.(types.String).ValueString()
-						m[k] = &nv
-					}
-
-					return m
-				}(),
+				ID:                 data.ID.ValueString(),
+				ConfigFields:       configFields,
+				ConfigFieldSecrets: configFieldSecrets,
 			})
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", "Unable to update app installation config, got error: "+err.Error())
@@ -495,6 +570,10 @@ func (r *AppInstallationResource) Update(ctx context.Context, req resource.Updat
 		}
 
 		data.ConfigFields = config.ConfigFields
+		data.SensitiveConfigFields = config.SensitiveConfigFields
+		data.ConfigFieldsWOVersion = config.ConfigFieldsWOVersion
+		data.ConfigFieldSecrets = config.ConfigFieldSecrets
+		data.Config = config.Config
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	}
 
@@ -520,6 +599,7 @@ func (r *AppInstallationResource) Update(ctx context.Context, req resource.Updat
 			ctx,
 			*r.providerData,
 			data.ID.ValueString(),
+			updateTimeout,
 			&resp.Diagnostics,
 		)
 	}
@@ -539,6 +619,12 @@ func (r *AppInstallationResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the app installation.
 	_, err := CallFlowsAPI[DeleteAppInstallationRequest, struct{}](*r.providerData, deleteAppInstallationPath, DeleteAppInstallationRequest{
 		ID: data.ID.ValueString(),
@@ -548,7 +634,7 @@ func (r *AppInstallationResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	r.WaitForDeleted(ctx, data.ID.ValueString(), &resp.Diagnostics)
+	r.WaitForDeleted(ctx, data.ID.ValueString(), deleteTimeout, &resp.Diagnostics)
 }
 
 func (r *AppInstallationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -558,54 +644,30 @@ func (r *AppInstallationResource) ImportState(ctx context.Context, req resource.
 func (r *AppInstallationResource) WaitForDeleted(
 	ctx context.Context,
 	id string,
+	timeout time.Duration,
 	dg *diag.Diagnostics,
 ) {
-	var status string
-
-	for i := range maxPollRetries {
-		appInstallation, err := CallFlowsAPI[GetAppInstallationRequest, GetAppInstallationResponse](
-			*r.providerData,
-			getAppInstallationPath,
-			GetAppInstallationRequest{
+	statusWaiter{
+		Kind:      "App Installation Deletion",
+		Subject:   fmt.Sprintf("App Installation %s", id),
+		LogFields: map[string]any{"app_installation_id": id},
+		Fetch: func() (string, error) {
+			appInstallation, err := CallFlowsAPI[GetAppInstallationRequest, GetAppInstallationResponse](*r.providerData, getAppInstallationPath, GetAppInstallationRequest{
 				ID: id,
-			},
-		)
-		if err != nil {
-			if err.Error() == "not found" {
-				// Success case
-				return
+			})
+			if err != nil {
+				if err.Error() == "not found" {
+					return statusDeleted, nil
+				}
+				return "", err
 			}
-
-			dg.AddError("Client Error", fmt.Sprintf("Unable to read app installation, got error: %s", err.Error()))
-			return
-		}
-
-		status = appInstallation.Status
-
-		if status != "draining" && status != "drained" {
-			dg.AddError(
-				"App Installation Deletion Failed",
-				fmt.Sprintf(`App Installation %s reached status %q instead of being deleted`, id, status),
-			)
-
-			return
-		}
-
-		tflog.Debug(ctx, "App Installation deletion status retry", map[string]any{
-			"app_installation_id": id,
-			"status":              appInstallation.Status,
-			"attempt":             i + 1,
-		})
-
-		// Transitional states, continue polling
-		time.Sleep(pollRetryInterval)
-	}
-
-	// Timeout reached
-	dg.AddError(
-		"App Installation Deletion Timeout",
-		fmt.Sprintf(`App Installation %s did not reach a deleted state within 5 minutes, last status was %q`, id, status),
-	)
+			return appInstallation.Status, nil
+		},
+		Target:  []string{statusDeleted},
+		Pending: drainingStatuses,
+		Failure: deletionFailureStatuses,
+		Timeout: timeout,
+	}.Wait(ctx, dg)
 }
 
 type waitForReadyValidator struct{}
@@ -641,6 +703,221 @@ func (v waitForReadyValidator) ValidateResource(ctx context.Context, req resourc
 	}
 }
 
+type configFieldsValidator struct{}
+
+func (v configFieldsValidator) Description(ctx context.Context) string {
+	return `"config" must be an object, and a key can only be set in one of "config_fields", "sensitive_config_fields", "config_fields_wo", "config_field_secrets" and "config".`
+}
+
+func (v configFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v configFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg AppInstallationResourceModel
+
+	diags := req.Config.Get(ctx, &cfg)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := types.MapNull(types.StringType)
+	if !cfg.Config.IsNull() && !cfg.Config.IsUnderlyingValueNull() && !cfg.Config.IsUnknown() && !cfg.Config.IsUnderlyingValueUnknown() {
+		elements, ok := dynamicElements(cfg.Config)
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config"),
+				"Invalid configuration",
+				`"config" must be an object, with the config fields as keys.`,
+			)
+			return
+		}
+
+		// Only the keys matter here.
+		configKeys := make(map[string]attr.Value, len(elements))
+		for key := range elements {
+			configKeys[key] = types.StringNull()
+		}
+		config = types.MapValueMust(types.StringType, configKeys)
+	}
+
+	seen := make(map[string]string)
+	for _, fields := range []struct {
+		attribute string
+		value     types.Map
+	}{
+		{"config_fields", cfg.ConfigFields},
+		{"sensitive_config_fields", cfg.SensitiveConfigFields},
+		{"config_fields_wo", cfg.ConfigFieldsWO},
+		{"config_field_secrets", cfg.ConfigFieldSecrets},
+		{"config", config},
+	} {
+		if fields.value.IsUnknown() {
+			continue
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(fields.value.Elements())) {
+			if other, ok := seen[key]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(fields.attribute).AtMapKey(key),
+					"Invalid configuration",
+					fmt.Sprintf("Config field %q is already set in %q.", key, other),
+				)
+				continue
+			}
+			seen[key] = fields.attribute
+		}
+	}
+}
+
+// configFieldsRequest merges config field maps into the fields of an UpdateAppInstallationConfigRequest,
+// sending null elements as removals.
+func configFieldsRequest(fieldMaps ...types.Map) map[string]*string {
+	m := make(map[string]*string)
+
+	for _, fields := range fieldMaps {
+		for k, v := range fields.Elements() {
+			if v.IsNull() {
+				m[k] = nil
+				continue
+			}
+
+			nv := v.(types.String).ValueString()
+			m[k] = &nv
+		}
+	}
+
+	return m
+}
+
+// addDynamicConfigFields adds the elements of the "config" attribute to the fields of an UpdateAppInstallationConfigRequest,
+// encoded as JSON, sending null elements as removals.
+func addDynamicConfigFields(ctx context.Context, fields map[string]*string, config types.Dynamic) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.IsNull() || config.IsUnderlyingValueNull() {
+		return diags
+	}
+
+	elements, ok := dynamicElements(config)
+	if !ok {
+		diags.AddAttributeError(path.Root("config"), "Invalid Config", `"config" must be an object, with the config fields as keys.`)
+		return diags
+	}
+
+	for key, element := range elements {
+		if element.IsNull() {
+			fields[key] = nil
+			continue
+		}
+
+		encoded, err := attrValueToJSON(ctx, element)
+		if err != nil {
+			diags.AddAttributeError(path.Root("config").AtMapKey(key), "Invalid Config", fmt.Sprintf("Unable to encode config field %q as JSON, got error: %s", key, err))
+			continue
+		}
+		fields[key] = &encoded
+	}
+
+	return diags
+}
+
+// refreshDynamicConfigFields returns the "config" attribute with the remote values of its config fields,
+// keeping the prior values which are equal as JSON, and leaving out fields which were not originally in the plan/state.
+func refreshDynamicConfigFields(ctx context.Context, config types.Dynamic, remote map[string]string) (types.Dynamic, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elements, ok := dynamicElements(config)
+	if !ok {
+		return config, diags
+	}
+
+	changed := false
+	attributeTypes := make(map[string]attr.Type, len(elements))
+	attributes := make(map[string]attr.Value, len(elements))
+	for key, element := range elements {
+		remoteValue, ok := remote[key]
+		if !ok {
+			if !element.IsNull() {
+				changed = true
+			}
+			continue
+		}
+
+		prior, err := attrValueToJSON(ctx, element)
+		if err == nil && jsonStringsEqual(types.StringValue(prior), types.StringValue(remoteValue)) {
+			attributeTypes[key] = element.Type(ctx)
+			attributes[key] = element
+			continue
+		}
+
+		value, err := jsonToAttrValue(ctx, remoteValue)
+		if err != nil {
+			diags.AddError("Invalid Config Field", fmt.Sprintf("Unable to decode config field %q as JSON, got error: %s", key, err))
+			return config, diags
+		}
+
+		changed = true
+		attributeTypes[key] = value.Type(ctx)
+		attributes[key] = value
+	}
+
+	if !changed {
+		return config, diags
+	}
+
+	object, objectDiags := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(objectDiags...)
+	if diags.HasError() {
+		return config, diags
+	}
+
+	return types.DynamicValue(object), diags
+}
+
+// configFieldSecretsRequest returns the secret bindings of an UpdateAppInstallationConfigRequest,
+// unbinding the fields which are no longer in the planned map.
+func configFieldSecretsRequest(prior, planned types.Map) map[string]*string {
+	m := configFieldsRequest(planned)
+
+	for k := range prior.Elements() {
+		if _, ok := m[k]; !ok {
+			m[k] = nil
+		}
+	}
+
+	return m
+}
+
+// refreshConfigFields returns the remote values of the config fields in the given map,
+// leaving out fields which were not originally in the plan/state.
+func refreshConfigFields(fields types.Map, remote map[string]string) types.Map {
+	if fields.IsNull() {
+		return fields
+	}
+
+	m := make(map[string]attr.Value)
+
+	for k, v := range remote {
+		prior, ok := fields.Elements()[k]
+		if !ok {
+			continue
+		}
+
+		// Keep the prior value if it only differs in formatting.
+		if priorString, ok := prior.(types.String); ok && jsonStringsEqual(priorString, types.StringValue(v)) {
+			m[k] = prior
+			continue
+		}
+
+		m[k] = types.StringValue(v)
+	}
+
+	return types.MapValueMust(types.StringType, m)
+}
+
 func ConfirmAppInstallation(
 	ctx context.Context,
 	provider FlowsProviderConfiguredData,
@@ -670,63 +947,30 @@ func WaitForAppInstallationReady(
 	ctx context.Context,
 	provider FlowsProviderConfiguredData,
 	id string,
+	timeout time.Duration,
 	dg *diag.Diagnostics,
 ) *string {
-	var status string
-
-	for i := range maxPollRetries {
-		appInstallation, err := CallFlowsAPI[GetAppInstallationStatusRequest, GetAppInstallationStatusResponse](
-			provider,
-			getAppInstallationStatusPath,
-			GetAppInstallationStatusRequest{
+	status, settled := statusWaiter{
+		Kind:      "App Installation",
+		Subject:   fmt.Sprintf("App Installation %s", id),
+		LogFields: map[string]any{"app_installation_id": id},
+		Fetch: func() (string, error) {
+			appInstallation, err := CallFlowsAPI[GetAppInstallationStatusRequest, GetAppInstallationStatusResponse](provider, getAppInstallationStatusPath, GetAppInstallationStatusRequest{
 				ID: id,
-			},
-		)
-		if err != nil {
-			dg.AddError("Client Error", fmt.Sprintf("Unable to read app installation status, got error: %s", err.Error()))
-			return nil
-		}
-
-		tflog.Debug(ctx, "App Installation confirmation status retry", map[string]any{
-			"app_installation_id": id,
-			"status":              appInstallation.Status,
-			"attempt":             i + 1,
-		})
-
-		status = appInstallation.Status
-
-		switch status {
-		case "ready":
-			// Success case
-			return &status
-		case "failed", "drifted", "draining_failed", "draining", "drained":
-			// Terminal failure states
-			dg.AddError(
-				"App Installation Failed",
-				fmt.Sprintf(`App Installation %q reached status %q instead of "ready"`, id, status),
-			)
-
-			return &status
-		case "draft", "in_progress":
-			// Transitional states, continue polling
-			time.Sleep(pollRetryInterval)
-			continue
-		default:
-			// Unknown status
-			dg.AddError(
-				"Unknown App Installation Status",
-				fmt.Sprintf(`App Installation %s has unknown status %q`, id, status),
-			)
-
-			return &status
-		}
+			})
+			if err != nil {
+				return "", err
+			}
+			return appInstallation.Status, nil
+		},
+		Target:  lifecycleReadyStatuses,
+		Pending: lifecyclePendingStatuses,
+		Failure: lifecycleFailureStatuses,
+		Timeout: timeout,
+	}.Wait(ctx, dg)
+	if !settled {
+		return nil
 	}
 
-	// Timeout reached
-	dg.AddError(
-		"App Installation Confirmation Timeout",
-		fmt.Sprintf(`App Installation %s did not reach a settled state within 5 minutes, last status was %q`, id, status),
-	)
-
-	return nil
+	return &status
 }
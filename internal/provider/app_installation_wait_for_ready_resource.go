@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -22,8 +24,9 @@ func NewAppInstallationWaitForReadyResource() resource.Resource {
 }
 
 type AppInstallationWaitForReadyResourceModel struct {
-	AppInstallationID types.String `tfsdk:"app_installation_id"`
-	Status            types.String `tfsdk:"status"`
+	AppInstallationID types.String   `tfsdk:"app_installation_id"`
+	Status            types.String   `tfsdk:"status"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AppInstallationWaitForReadyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +50,10 @@ You must ensure that either app installation "confirm" is set to true or you are
 				MarkdownDescription: `The final status of the app installation after waiting for it to be "ready".`,
 				Computed:            true,
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: `How long to wait for the app installation to be ready. Defaults to 5 minutes.`,
+			}),
 		},
 	}
 }
@@ -69,12 +76,19 @@ func (r *AppInstallationWaitForReadyResource) Create(ctx context.Context, req re
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	appInstallationID := data.AppInstallationID.ValueString()
 
 	status := WaitForAppInstallationReady(
 		ctx,
 		*r.providerData,
 		appInstallationID,
+		createTimeout,
 		&resp.Diagnostics,
 	)
 	if status == nil {
@@ -113,11 +127,20 @@ func (r *AppInstallationWaitForReadyResource) Read(ctx context.Context, req reso
 }
 
 func (r *AppInstallationWaitForReadyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// This resource doesn't support updates
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		`The "app_installation_wait_for_ready" resource does not support updates. Please destroy and recreate.`,
-	)
+	var data AppInstallationWaitForReadyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every argument other than the timeouts requires replacement, so only those can change here.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AppInstallationWaitForReadyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
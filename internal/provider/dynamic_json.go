@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dynamicElements returns the elements of a dynamic value holding an object or a map,
+// and whether it holds one.
+func dynamicElements(value types.Dynamic) (map[string]attr.Value, bool) {
+	switch underlying := value.UnderlyingValue().(type) {
+	case types.Object:
+		return underlying.Attributes(), true
+	case types.Map:
+		return underlying.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
+// attrValueToJSON encodes a known Terraform value as JSON.
+func attrValueToJSON(ctx context.Context, value attr.Value) (string, error) {
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	goValue, err := terraformValueToGo(terraformValue)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(goValue)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func terraformValueToGo(value tftypes.Value) (any, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		if n.IsInt() {
+			return json.Number(n.Text('f', 0)), nil
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]any, 0, len(elements))
+		for _, element := range elements {
+			goElement, err := terraformValueToGo(element)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, goElement)
+		}
+		return result, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, len(elements))
+		for key, element := range elements {
+			goElement, err := terraformValueToGo(element)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = goElement
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+// jsonToAttrValue decodes JSON into a Terraform value, using tuples for arrays and objects for JSON objects,
+// so that their elements can be of different types.
+func jsonToAttrValue(ctx context.Context, encoded string) (attr.Value, error) {
+	goValue, err := decodeJSON(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return goToAttrValue(ctx, goValue)
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as json.Number so that they don't lose precision.
+func decodeJSON(encoded string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(encoded))
+	decoder.UseNumber()
+
+	var goValue any
+	if err := decoder.Decode(&goValue); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return goValue, nil
+}
+
+// jsonValuesEqual reports whether two values decoded by decodeJSON are equal, comparing numbers by value.
+func jsonValuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		numberA, _, errA := big.ParseFloat(a.String(), 10, 512, big.ToNearestEven)
+		numberB, _, errB := big.ParseFloat(b.String(), 10, 512, big.ToNearestEven)
+		if errA != nil || errB != nil {
+			return a == b
+		}
+		return numberA.Cmp(numberB) == 0
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonValuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, valueA := range a {
+			valueB, ok := b[key]
+			if !ok || !jsonValuesEqual(valueA, valueB) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func goToAttrValue(ctx context.Context, goValue any) (attr.Value, error) {
+	switch v := goValue.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		n, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(n), nil
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+		for _, goElement := range v {
+			element, err := goToAttrValue(ctx, goElement)
+			if err != nil {
+				return nil, err
+			}
+			elementTypes = append(elementTypes, element.Type(ctx))
+			elements = append(elements, element)
+		}
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]any:
+		attributeTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for key, goAttribute := range v {
+			attribute, err := goToAttrValue(ctx, goAttribute)
+			if err != nil {
+				return nil, err
+			}
+			attributeTypes[key] = attribute.Type(ctx)
+			attributes[key] = attribute
+		}
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build object: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value %T", goValue)
+	}
+}
@@ -3,8 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -27,8 +28,9 @@ type EntityConfirmationResource struct {
 
 // EntityConfirmationResourceModel describes the resource data model.
 type EntityConfirmationResourceModel struct {
-	EntityId types.String `tfsdk:"entity_id"`
-	Status   types.String `tfsdk:"status"`
+	EntityId types.String   `tfsdk:"entity_id"`
+	Status   types.String   `tfsdk:"status"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *EntityConfirmationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +54,10 @@ This is useful for creating stateful blocks and entities using the flow resource
 				MarkdownDescription: "The final status of the entity after confirmation",
 				Computed:            true,
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: "How long to wait for the entity to reach a settled state. Defaults to 5 minutes.",
+			}),
 		},
 	}
 }
@@ -86,6 +92,12 @@ func (r *EntityConfirmationResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	entityID := data.EntityId.ValueString()
 
 	// First check the current status
@@ -118,60 +130,35 @@ func (r *EntityConfirmationResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	// Poll for the status to settle
-	maxRetries := 60 // 5 minutes with 5-second intervals
-	retryInterval := 5 * time.Second
-	var finalStatus string
+	status, settled := statusWaiter{
+		Kind:      "Entity Confirmation",
+		Subject:   fmt.Sprintf("Entity %s", entityID),
+		LogFields: map[string]any{"entity_id": entityID},
+		Fetch:     entityLifecycleStatusFetcher(*r.providerData, entityID),
+		Target:    lifecycleReadyStatuses,
+		Pending:   lifecyclePendingStatuses,
+		Failure:   lifecycleFailureStatuses,
+		Timeout:   createTimeout,
+	}.Wait(ctx, &resp.Diagnostics)
+	if !settled || resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Status = types.StringValue(status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	for i := 0; i < maxRetries; i++ {
-		statusResp, err := CallFlowsAPI[GetEntityLifecycleStatusRequest, GetEntityLifecycleStatusResponse](*r.providerData, "/provider/flows/get_entity_lifecycle_status", GetEntityLifecycleStatusRequest{
+// entityLifecycleStatusFetcher returns a status fetcher for the entity, for use with statusWaiter.
+func entityLifecycleStatusFetcher(provider FlowsProviderConfiguredData, entityID string) func() (string, error) {
+	return func() (string, error) {
+		statusResp, err := CallFlowsAPI[GetEntityLifecycleStatusRequest, GetEntityLifecycleStatusResponse](provider, "/provider/flows/get_entity_lifecycle_status", GetEntityLifecycleStatusRequest{
 			EntityID: entityID,
 		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get entity status, got error: %s", err))
-			return
-		}
-
-		tflog.Debug(ctx, "Entity status", map[string]interface{}{
-			"entity_id": entityID,
-			"status":    statusResp.Status,
-			"attempt":   i + 1,
-		})
-
-		finalStatus = statusResp.Status
-
-		// Check if the status is settled
-		switch finalStatus {
-		case "ready":
-			// Success case
-			data.Status = types.StringValue(finalStatus)
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			return
-		case "failed", "drifted", "draining_failed", "draining", "drained":
-			// Terminal failure states
-			resp.Diagnostics.AddError(
-				"Entity Confirmation Failed",
-				fmt.Sprintf("Entity %q reached status '%s' instead of 'ready'", entityID, finalStatus),
-			)
-			return
-		case "draft", "in_progress":
-			// Transitional states, continue polling
-			time.Sleep(retryInterval)
-			continue
-		default:
-			// Unknown status
-			resp.Diagnostics.AddError(
-				"Unknown Entity Status",
-				fmt.Sprintf("Entity %s has unknown status '%s'", entityID, finalStatus),
-			)
-			return
+			return "", err
 		}
+		return statusResp.Status, nil
 	}
-
-	// Timeout reached
-	resp.Diagnostics.AddError(
-		"Entity Confirmation Timeout",
-		fmt.Sprintf("Entity %s did not reach a settled state within 5 minutes, last status was '%s'", entityID, finalStatus),
-	)
 }
 
 func (r *EntityConfirmationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -198,11 +185,20 @@ func (r *EntityConfirmationResource) Read(ctx context.Context, req resource.Read
 }
 
 func (r *EntityConfirmationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// This resource doesn't support updates
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"The entity_confirmation resource does not support updates. Please destroy and recreate.",
-	)
+	var data EntityConfirmationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every argument other than the timeouts requires replacement, so only those can change here.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *EntityConfirmationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
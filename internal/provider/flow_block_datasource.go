@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &FlowBlockDataSource{}
+	_ datasource.DataSourceWithConfigure = &FlowBlockDataSource{}
+)
+
+type FlowBlockDataSource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+func (ds *FlowBlockDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func NewFlowBlockDataSource() datasource.DataSource {
+	return &FlowBlockDataSource{}
+}
+
+type FlowBlockDataSourceModel struct {
+	FlowID            types.String `tfsdk:"flow_id"`
+	Name              types.String `tfsdk:"name"`
+	ID                types.String `tfsdk:"id"`
+	Type              types.String `tfsdk:"type"`
+	AppInstallationID types.String `tfsdk:"app_installation_id"`
+	AppKey            types.String `tfsdk:"app_key"`
+	PublicURL         types.String `tfsdk:"public_url"`
+}
+
+func (ds *FlowBlockDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_block"
+}
+
+func (ds *FlowBlockDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source for looking up a single block of a flow by its name.",
+		Attributes: map[string]schema.Attribute{
+			"flow_id": schema.StringAttribute{
+				Description: "ID of the flow containing the block.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the block.",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the block.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the block.",
+				Computed:    true,
+			},
+			"app_installation_id": schema.StringAttribute{
+				Description: "ID of the app installation providing the block, if any.",
+				Computed:    true,
+			},
+			"app_key": schema.StringAttribute{
+				Description: "Key of the app providing the block, if any.",
+				Computed:    true,
+			},
+			"public_url": schema.StringAttribute{
+				Description: "Public URL of the block, for blocks receiving HTTP requests, like webhooks.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (ds *FlowBlockDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FlowBlockDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getFlowResp, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](*ds.providerData, "/provider/flows/get", GetFlowRequest{
+		FlowID: data.FlowID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to fetch flow, got error: "+err.Error())
+		return
+	}
+
+	block, ok := getFlowResp.Blocks[data.Name.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddError("Block Not Found", fmt.Sprintf("Flow %s has no block named %q.", data.FlowID.ValueString(), data.Name.ValueString()))
+		return
+	}
+
+	data.ID = types.StringValue(block.ID)
+	data.Type = types.StringValue(block.Type)
+	data.AppInstallationID = types.StringPointerValue(block.AppInstallationID)
+	data.AppKey = types.StringPointerValue(block.AppKey)
+	data.PublicURL = types.StringPointerValue(block.PublicURL)
+
+	// Write state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// flowBlockEntityIDs returns the entity IDs of the blocks in the "blocks" attribute which are part of the rendered definition,
+// keyed by block name. All blocks are returned if the definition is empty or can't be parsed.
+func flowBlockEntityIDs(blocks types.Map, definition string) map[string]string {
+	parsed, err := parseFlowDefinition(definition)
+	filter := definition != "" && err == nil
+
+	entityIDs := make(map[string]string)
+	for name, block := range blocks.Elements() {
+		if filter {
+			if _, ok := parsed.Blocks[name]; !ok {
+				continue
+			}
+		}
+
+		blockObject, ok := block.(types.Object)
+		if !ok {
+			continue
+		}
+		id, ok := blockObject.Attributes()["id"].(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+		entityIDs[name] = id.ValueString()
+	}
+
+	return entityIDs
+}
+
+// ConfirmFlowBlocks confirms the entities of the given blocks which are in draft.
+func ConfirmFlowBlocks(
+	ctx context.Context,
+	provider FlowsProviderConfiguredData,
+	entityIDs map[string]string,
+	dg *diag.Diagnostics,
+) {
+	for _, name := range slices.Sorted(maps.Keys(entityIDs)) {
+		entityID := entityIDs[name]
+
+		statusResp, err := CallFlowsAPI[GetEntityLifecycleStatusRequest, GetEntityLifecycleStatusResponse](provider, "/provider/flows/get_entity_lifecycle_status", GetEntityLifecycleStatusRequest{
+			EntityID: entityID,
+		})
+		if err != nil {
+			dg.AddError("Client Error", fmt.Sprintf("Unable to get status of block %q, got error: %s", name, err))
+			continue
+		}
+		if statusResp.Status != "draft" {
+			continue
+		}
+
+		tflog.Info(ctx, "Confirming block entity", map[string]any{
+			"block_name": name,
+			"entity_id":  entityID,
+		})
+
+		_, err = CallFlowsAPI[ConfirmEntityLifecycleRequest, struct{}](provider, "/provider/flows/confirm_entity_lifecycle", ConfirmEntityLifecycleRequest{
+			ID: entityID,
+		})
+		if err != nil {
+			dg.AddError("Client Error", fmt.Sprintf("Unable to confirm block %q, got error: %s", name, err))
+		}
+	}
+}
+
+// WaitForFlowBlocksReady polls the entities of the given blocks until all of them are ready,
+// reporting an error for every block which fails or doesn't settle in time.
+func WaitForFlowBlocksReady(
+	ctx context.Context,
+	provider FlowsProviderConfiguredData,
+	entityIDs map[string]string,
+	timeout time.Duration,
+	dg *diag.Diagnostics,
+) {
+	deadline := time.Now().Add(timeout)
+
+	for _, name := range slices.Sorted(maps.Keys(entityIDs)) {
+		entityID := entityIDs[name]
+
+		statusWaiter{
+			Kind:      "Flow Block",
+			Subject:   fmt.Sprintf("Block %q (entity %s)", name, entityID),
+			LogFields: map[string]any{"block_name": name, "entity_id": entityID},
+			Fetch:     entityLifecycleStatusFetcher(provider, entityID),
+			Target:    lifecycleReadyStatuses,
+			Pending:   lifecyclePendingStatuses,
+			Failure:   lifecycleFailureStatuses,
+			Timeout:   remaining(deadline),
+		}.Wait(ctx, dg)
+	}
+}
+
+// WaitForFlowDeleted polls the deleted flow and the entities of its blocks until all of them are gone,
+// so that the app installations they use can be deleted right after.
+func WaitForFlowDeleted(
+	ctx context.Context,
+	provider FlowsProviderConfiguredData,
+	flowID string,
+	entityIDs map[string]string,
+	timeout time.Duration,
+	dg *diag.Diagnostics,
+) {
+	deadline := time.Now().Add(timeout)
+
+	_, settled := statusWaiter{
+		Kind:      "Flow Deletion",
+		Subject:   fmt.Sprintf("Flow %s", flowID),
+		LogFields: map[string]any{"flow_id": flowID},
+		Fetch: func() (string, error) {
+			_, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](provider, "/provider/flows/get", GetFlowRequest{
+				FlowID: flowID,
+			})
+			if err != nil {
+				if err.Error() == "not found" {
+					return statusDeleted, nil
+				}
+				return "", err
+			}
+			// The flow has no status of its own, it's still being deleted while it can be read.
+			return "deleting", nil
+		},
+		Target:  []string{statusDeleted},
+		Pending: []string{"deleting"},
+		Timeout: remaining(deadline),
+	}.Wait(ctx, dg)
+	if !settled || dg.HasError() {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(entityIDs)) {
+		entityID := entityIDs[name]
+		fetchStatus := entityLifecycleStatusFetcher(provider, entityID)
+
+		_, settled := statusWaiter{
+			Kind:      "Flow Deletion",
+			Subject:   fmt.Sprintf("Block %q (entity %s)", name, entityID),
+			LogFields: map[string]any{"flow_id": flowID, "block_name": name, "entity_id": entityID},
+			Fetch: func() (string, error) {
+				status, err := fetchStatus()
+				if err != nil && err.Error() == "not found" {
+					return statusDeleted, nil
+				}
+				return status, err
+			},
+			Target:  []string{statusDeleted},
+			Pending: flowDeletionPendingStatuses,
+			Failure: flowDeletionFailureStatuses,
+			Timeout: remaining(deadline),
+		}.Wait(ctx, dg)
+		if !settled || dg.HasError() {
+			return
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &FlowBlockResource{}
+	_ resource.ResourceWithImportState = &FlowBlockResource{}
+)
+
+func NewFlowBlockResource() resource.Resource {
+	return &FlowBlockResource{}
+}
+
+type FlowBlockResource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+type FlowBlockResourceModel struct {
+	FlowID            types.String `tfsdk:"flow_id"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	App               types.String `tfsdk:"app"`
+	AppInstallationID types.String `tfsdk:"app_installation_id"`
+	Config            types.String `tfsdk:"config"`
+	Position          types.Object `tfsdk:"position"`
+	Connections       types.List   `tfsdk:"connections"`
+	ID                types.String `tfsdk:"id"`
+	PublicURL         types.String `tfsdk:"public_url"`
+}
+
+func (m FlowBlockResourceModel) block() FlowBlockModel {
+	return FlowBlockModel{
+		Name:              m.Name,
+		Type:              m.Type,
+		App:               m.App,
+		AppInstallationID: m.AppInstallationID,
+		Config:            m.Config,
+		Position:          m.Position,
+		Connections:       m.Connections,
+	}
+}
+
+func (r *FlowBlockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_block"
+}
+
+func (r *FlowBlockResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := flowBlockSchemaAttributes(schema.StringAttribute{
+		Description: "Name of the block, unique within the flow.",
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	})
+	attributes["flow_id"] = schema.StringAttribute{
+		Description: "ID of the flow containing the block.",
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["id"] = schema.StringAttribute{
+		Description: "ID of the block.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["public_url"] = schema.StringAttribute{
+		Description: "Public URL of the block, for blocks receiving HTTP requests, like webhooks.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a single block inside an existing flow, leaving the flow's other blocks alone.
+
+This allows multiple teams to own different blocks of one flow. If the flow itself is managed by a "flows_flow" resource, set its "managed_blocks" to "defined", so that it doesn't remove the blocks managed here.`,
+		Attributes: attributes,
+	}
+}
+
+func (r *FlowBlockResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func (r *FlowBlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FlowBlockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowBlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FlowBlockResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exportRes, err := CallFlowsAPI[ExportFlowDefinitionRequest, ExportFlowDefinitionResponse](*r.providerData, "/provider/flows/export_definition", ExportFlowDefinitionRequest{
+		FlowID: data.FlowID.ValueString(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", "Unable to fetch flow definition, got error: "+err.Error())
+		return
+	}
+
+	exported, err := parseFlowDefinition(exportRes.Definition)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Flow Definition", "Unable to parse the exported flow definition, got error: "+err.Error())
+		return
+	}
+
+	block, ok := exported.Blocks[data.Name.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	blockValue, diags := flowBlockValue(data.Name.ValueString(), block)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blockModel FlowBlockModel
+	resp.Diagnostics.Append(blockValue.As(ctx, &blockModel, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Type = blockModel.Type
+	data.App = blockModel.App
+	data.AppInstallationID = blockModel.AppInstallationID
+	if !jsonStringsEqual(data.Config, blockModel.Config) {
+		data.Config = blockModel.Config
+	}
+	data.Position = blockModel.Position
+	// The definition doesn't distinguish a block without connections from one with an empty list of them.
+	if !blockModel.Connections.IsNull() || len(data.Connections.Elements()) > 0 {
+		data.Connections = blockModel.Connections
+	}
+
+	r.readDetails(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowBlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FlowBlockResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowBlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FlowBlockResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Applying a definition without the block, limited to the block, removes it.
+	err := r.applyDefinition(data.FlowID.ValueString(), data.Name.ValueString(), &flowDefinition{})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", "Unable to delete flow block, got error: "+err.Error())
+		return
+	}
+}
+
+func (r *FlowBlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	flowID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || flowID == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected an import ID of the form <flow_id>/<block_name>, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flow_id"), flowID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// apply creates or updates the block in the flow, and reads back its ID and public URL.
+func (r *FlowBlockResource) apply(ctx context.Context, data *FlowBlockResourceModel, diags *diag.Diagnostics) {
+	block, blockDiags := flowDefinitionBlockFromModel(ctx, data.block(), path.Root("config"))
+	diags.Append(blockDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	err := r.applyDefinition(data.FlowID.ValueString(), data.Name.ValueString(), &flowDefinition{
+		Blocks: map[string]*flowDefinitionBlock{
+			data.Name.ValueString(): block,
+		},
+	})
+	if err != nil {
+		diags.AddError("Client Error", "Unable to apply flow block, got error: "+err.Error())
+		return
+	}
+
+	r.readDetails(ctx, data, diags)
+}
+
+// applyDefinition applies the definition to the flow, limited to the given block.
+func (r *FlowBlockResource) applyDefinition(flowID string, name string, definition *flowDefinition) error {
+	serialized, err := definition.canonical()
+	if err != nil {
+		return err
+	}
+
+	_, err = CallFlowsAPI[ApplyFlowConfigRequest, struct{}](*r.providerData, "/provider/flows/apply_config", ApplyFlowConfigRequest{
+		FlowID:     flowID,
+		Definition: serialized,
+		OnlyBlocks: &[]string{name},
+	})
+	return err
+}
+
+func (r *FlowBlockResource) readDetails(ctx context.Context, data *FlowBlockResourceModel, diags *diag.Diagnostics) {
+	getFlowResp, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](*r.providerData, "/provider/flows/get", GetFlowRequest{
+		FlowID: data.FlowID.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Client Error", "Unable to fetch flow, got error: "+err.Error())
+		return
+	}
+
+	block, ok := getFlowResp.Blocks[data.Name.ValueString()]
+	if !ok {
+		diags.AddError("Block Not Found", fmt.Sprintf("Flow %s has no block named %q.", data.FlowID.ValueString(), data.Name.ValueString()))
+		return
+	}
+
+	data.ID = types.StringValue(block.ID)
+	data.PublicURL = types.StringPointerValue(block.PublicURL)
+}
+
+// jsonStringsEqual reports whether both values hold the same JSON value, regardless of formatting.
+func jsonStringsEqual(a, b types.String) bool {
+	if a.Equal(b) {
+		return true
+	}
+	if a.IsNull() || b.IsNull() {
+		return false
+	}
+
+	valueA, err := decodeJSON(a.ValueString())
+	if err != nil {
+		return false
+	}
+	valueB, err := decodeJSON(b.ValueString())
+	if err != nil {
+		return false
+	}
+
+	return jsonValuesEqual(valueA, valueB)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                     = &FlowDataSource{}
+	_ datasource.DataSourceWithConfigure        = &FlowDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &FlowDataSource{}
+)
+
+type FlowDataSource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+func (ds *FlowDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func NewFlowDataSource() datasource.DataSource {
+	return &FlowDataSource{}
+}
+
+type FlowDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Name       types.String `tfsdk:"name"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Definition types.String `tfsdk:"definition"`
+	Blocks     types.Map    `tfsdk:"blocks"`
+}
+
+func (ds *FlowDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow"
+}
+
+func (ds *FlowDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (ds *FlowDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source for looking up an existing flow, e.g. one managed by another team, either by its ID or by its project and name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the flow. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project containing the flow. Required when looking up the flow by `name`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the flow. Must be unique within the project when used for the lookup.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("project_id")),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the flow is running.",
+				Computed:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "YAML definition of the flow, as exported from the Flows API.",
+				Computed:    true,
+			},
+			"blocks": schema.MapAttribute{
+				Description: "Map of blocks in the flow, keyed by their names. Each block exposes its ID, type, app key and app installation ID, and for blocks receiving HTTP requests, like webhooks, their public URL.",
+				Computed:    true,
+				ElementType: types.ObjectType{
+					AttrTypes: flowBlockDetailsAttrTypes,
+				},
+			},
+		},
+	}
+}
+
+func (ds *FlowDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FlowDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flowID := data.ID.ValueString()
+	if data.ID.IsNull() {
+		listFlowsResp, err := CallFlowsAPI[ListFlowsRequest, ListFlowsResponse](*ds.providerData, listFlowsPath, ListFlowsRequest{
+			ProjectID: data.ProjectID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to list flows, got error: "+err.Error())
+			return
+		}
+
+		var matches []string
+		for _, flow := range listFlowsResp.Flows {
+			if flow.Name == data.Name.ValueString() {
+				matches = append(matches, flow.ID)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddError("Flow Not Found", fmt.Sprintf("Project %s has no flow named %q.", data.ProjectID.ValueString(), data.Name.ValueString()))
+			return
+		case 1:
+			flowID = matches[0]
+		default:
+			resp.Diagnostics.AddError("Multiple Flows Found", fmt.Sprintf("Project %s has %d flows named %q, look the flow up by its ID instead.", data.ProjectID.ValueString(), len(matches), data.Name.ValueString()))
+			return
+		}
+	}
+
+	flowDetails, err := getFlowDetails(ctx, *ds.providerData, flowID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch flow details", err.Error())
+		return
+	}
+
+	exportRes, err := CallFlowsAPI[ExportFlowDefinitionRequest, ExportFlowDefinitionResponse](*ds.providerData, "/provider/flows/export_definition", ExportFlowDefinitionRequest{
+		FlowID: flowID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to export flow definition", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(flowID)
+	if !flowDetails.ProjectID.IsNull() {
+		data.ProjectID = flowDetails.ProjectID
+	}
+	data.Name = flowDetails.Name
+	data.Enabled = flowDetails.Enabled
+	data.Definition = types.StringValue(exportRes.Definition)
+	data.Blocks = flowDetails.Blocks
+
+	// Write state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
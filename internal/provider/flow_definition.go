@@ -0,0 +1,528 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
+)
+
+// flowDefinition is the model of a flow definition, in the YAML format produced by copying blocks in the Flows UI.
+// Only the parts the provider needs to reason about are modeled, everything else is kept as-is.
+type flowDefinition struct {
+	Blocks map[string]*flowDefinitionBlock `yaml:"blocks,omitempty"`
+	Other  map[string]any                  `yaml:",inline"`
+}
+
+type flowDefinitionBlock struct {
+	Type              string                     `yaml:"type,omitempty"`
+	App               string                     `yaml:"app,omitempty"`
+	AppInstallationID string                     `yaml:"appInstallationId,omitempty"`
+	Config            map[string]any             `yaml:"config,omitempty"`
+	Position          *flowDefinitionPosition    `yaml:"position,omitempty"`
+	Connections       []flowDefinitionConnection `yaml:"connections,omitempty"`
+	Other             map[string]any             `yaml:",inline"`
+}
+
+type flowDefinitionPosition struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+}
+
+type flowDefinitionConnection struct {
+	Output string         `yaml:"output,omitempty"`
+	Target string         `yaml:"target"`
+	Other  map[string]any `yaml:",inline"`
+}
+
+func parseFlowDefinition(definition string) (*flowDefinition, error) {
+	var parsed flowDefinition
+	if err := yaml.Unmarshal([]byte(definition), &parsed); err != nil {
+		return nil, fmt.Errorf("could not parse flow definition: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+// canonical serializes the definition with sorted keys and without comments or custom quoting,
+// so that two definitions with the same meaning serialize identically.
+func (d *flowDefinition) canonical() (string, error) {
+	out, err := yaml.Marshal(d)
+	if err != nil {
+		return "", fmt.Errorf("could not serialize flow definition: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// flowDefinitionsEqual reports whether two definitions only differ in formatting.
+// Definitions that can't be parsed are compared verbatim.
+func flowDefinitionsEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	canonicalA, err := canonicalFlowDefinition(a)
+	if err != nil {
+		return false
+	}
+	canonicalB, err := canonicalFlowDefinition(b)
+	if err != nil {
+		return false
+	}
+
+	return canonicalA == canonicalB
+}
+
+func canonicalFlowDefinition(definition string) (string, error) {
+	parsed, err := parseFlowDefinition(definition)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.canonical()
+}
+
+func flowDefinitionSHA256(definition string) string {
+	sum := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(sum[:])
+}
+
+// listFlowDefinitionDir returns the YAML files directly inside the directory, sorted by name.
+func listFlowDefinitionDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read definition directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml":
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("definition directory %q contains no YAML files", dir)
+	}
+
+	return files, nil
+}
+
+// loadFlowDefinitionFiles merges the definition fragments in the given files into a single definition.
+// Each file is a definition on its own, and no two files may define the same block.
+func loadFlowDefinitionFiles(files []string) (*flowDefinition, error) {
+	merged := &flowDefinition{
+		Blocks: make(map[string]*flowDefinitionBlock),
+		Other:  make(map[string]any),
+	}
+	blockFiles := make(map[string]string)
+	otherFiles := make(map[string]string)
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("could not read definition file: %w", err)
+		}
+
+		fragment, err := parseFlowDefinition(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		for name, block := range fragment.Blocks {
+			if otherFile, ok := blockFiles[name]; ok {
+				return nil, fmt.Errorf("block %q is defined in both %s and %s", name, otherFile, file)
+			}
+			blockFiles[name] = file
+			merged.Blocks[name] = block
+		}
+
+		for key, value := range fragment.Other {
+			if otherValue, ok := merged.Other[key]; ok && !reflect.DeepEqual(value, otherValue) {
+				return nil, fmt.Errorf("%q is set to different values in %s and %s", key, otherFiles[key], file)
+			}
+			otherFiles[key] = file
+			merged.Other[key] = value
+		}
+	}
+
+	return merged, nil
+}
+
+// flowDefinitionVariablePattern matches "{{ var.NAME }}" placeholders, optionally escaped with a leading backslash.
+var flowDefinitionVariablePattern = regexp.MustCompile(`(\\?)\{\{\s*var\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// renderFlowDefinition substitutes the variable placeholders in the string values of the definition with their values.
+// Escaped placeholders are kept literally, without the backslash.
+// Values are substituted in the parsed YAML rather than in its text, so that they can't change the structure of the definition.
+func renderFlowDefinition(definition string, variables map[string]string) (string, error) {
+	if !flowDefinitionVariablePattern.MatchString(definition) {
+		return definition, nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &document); err != nil {
+		return "", fmt.Errorf("unable to parse the flow definition: %w", err)
+	}
+
+	undefined := make(map[string]struct{})
+	renderFlowDefinitionNode(&document, variables, undefined)
+
+	if len(undefined) > 0 {
+		names := make([]string, 0, len(undefined))
+		for name := range undefined {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return "", fmt.Errorf("the flow definition uses undefined variables: %s", strings.Join(names, ", "))
+	}
+
+	out, err := yaml.Marshal(&document)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the flow definition: %w", err)
+	}
+	return string(out), nil
+}
+
+// escapeFlowDefinitionVariables escapes the variable placeholders in the string values of an already rendered definition,
+// e.g. one exported from the Flows API, so that rendering it again leaves them as they are.
+func escapeFlowDefinitionVariables(definition string) (string, error) {
+	if !flowDefinitionVariablePattern.MatchString(definition) {
+		return definition, nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &document); err != nil {
+		return "", fmt.Errorf("unable to parse the flow definition: %w", err)
+	}
+
+	var escape func(node *yaml.Node)
+	escape = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+			// Escaped placeholders get another backslash, which rendering removes again.
+			node.Value = flowDefinitionVariablePattern.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+				return `\` + placeholder
+			})
+		}
+		for _, child := range node.Content {
+			escape(child)
+		}
+	}
+	escape(&document)
+
+	out, err := yaml.Marshal(&document)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the flow definition: %w", err)
+	}
+	return string(out), nil
+}
+
+// renderFlowDefinitionNode substitutes the variable placeholders in the string scalars of the node and its children,
+// recording the names of undefined variables in undefined.
+func renderFlowDefinitionNode(node *yaml.Node, variables map[string]string, undefined map[string]struct{}) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+		node.Value = flowDefinitionVariablePattern.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			match := flowDefinitionVariablePattern.FindStringSubmatch(placeholder)
+			if match[1] != "" {
+				return strings.TrimPrefix(placeholder, match[1])
+			}
+
+			value, ok := variables[match[2]]
+			if !ok {
+				undefined[match[2]] = struct{}{}
+				return placeholder
+			}
+			return value
+		})
+		// Keep the value a string, whatever it now looks like.
+		node.Tag = "!!str"
+	}
+
+	for _, child := range node.Content {
+		renderFlowDefinitionNode(child, variables, undefined)
+	}
+}
+
+// flowDefinitionReferencePattern matches "{{ data_table.KEY }}" and "{{ secret.KEY }}" references,
+// which the Flows API resolves using the data table and secret mappings.
+var flowDefinitionReferencePattern = regexp.MustCompile(`\{\{\s*(data_table|secret)\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+const (
+	flowDefinitionReferenceDataTable = "data_table"
+	flowDefinitionReferenceSecret    = "secret"
+)
+
+// flowDefinitionReferences returns the keys referenced in the string values of the rendered definition, by kind of reference.
+func flowDefinitionReferences(definition string) (map[string]map[string]struct{}, error) {
+	references := map[string]map[string]struct{}{
+		flowDefinitionReferenceDataTable: {},
+		flowDefinitionReferenceSecret:    {},
+	}
+	if !flowDefinitionReferencePattern.MatchString(definition) {
+		return references, nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &document); err != nil {
+		return nil, fmt.Errorf("unable to parse the flow definition: %w", err)
+	}
+
+	var collect func(node *yaml.Node)
+	collect = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+			for _, match := range flowDefinitionReferencePattern.FindAllStringSubmatch(node.Value, -1) {
+				references[match[1]][match[2]] = struct{}{}
+			}
+		}
+		for _, child := range node.Content {
+			collect(child)
+		}
+	}
+	collect(&document)
+
+	return references, nil
+}
+
+var flowBlockPositionAttrTypes = map[string]attr.Type{
+	"x": types.Float64Type,
+	"y": types.Float64Type,
+}
+
+var flowBlockConnectionAttrTypes = map[string]attr.Type{
+	"output": types.StringType,
+	"target": types.StringType,
+}
+
+var flowBlockAttrTypes = map[string]attr.Type{
+	"name":                types.StringType,
+	"type":                types.StringType,
+	"app":                 types.StringType,
+	"app_installation_id": types.StringType,
+	"config":              types.StringType,
+	"position":            types.ObjectType{AttrTypes: flowBlockPositionAttrTypes},
+	"connections":         types.ListType{ElemType: types.ObjectType{AttrTypes: flowBlockConnectionAttrTypes}},
+}
+
+// FlowBlockModel describes a single element of the flow resource's "block" attribute.
+type FlowBlockModel struct {
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	App               types.String `tfsdk:"app"`
+	AppInstallationID types.String `tfsdk:"app_installation_id"`
+	Config            types.String `tfsdk:"config"`
+	Position          types.Object `tfsdk:"position"`
+	Connections       types.List   `tfsdk:"connections"`
+}
+
+type FlowBlockPositionModel struct {
+	X types.Float64 `tfsdk:"x"`
+	Y types.Float64 `tfsdk:"y"`
+}
+
+type FlowBlockConnectionModel struct {
+	Output types.String `tfsdk:"output"`
+	Target types.String `tfsdk:"target"`
+}
+
+// flowDefinitionFromBlocks builds a definition out of the "block" attribute, which must be known.
+func flowDefinitionFromBlocks(ctx context.Context, blocks types.Set) (*flowDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var blockModels []FlowBlockModel
+	diags.Append(blocks.ElementsAs(ctx, &blockModels, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	definition := &flowDefinition{
+		Blocks: make(map[string]*flowDefinitionBlock, len(blockModels)),
+	}
+	for _, blockModel := range blockModels {
+		name := blockModel.Name.ValueString()
+		if _, ok := definition.Blocks[name]; ok {
+			diags.AddAttributeError(path.Root("block"), "Duplicate Block Name", fmt.Sprintf("The block name %q is used more than once.", name))
+			continue
+		}
+
+		block, blockDiags := flowDefinitionBlockFromModel(ctx, blockModel, path.Root("block"))
+		diags.Append(blockDiags...)
+		if blockDiags.HasError() {
+			continue
+		}
+
+		definition.Blocks[name] = block
+	}
+
+	return definition, diags
+}
+
+// flowDefinitionBlockFromModel converts a single block described in HCL into its definition.
+// Errors are reported on the given attribute path.
+func flowDefinitionBlockFromModel(ctx context.Context, blockModel FlowBlockModel, attrPath path.Path) (*flowDefinitionBlock, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	block := &flowDefinitionBlock{
+		Type:              blockModel.Type.ValueString(),
+		App:               blockModel.App.ValueString(),
+		AppInstallationID: blockModel.AppInstallationID.ValueString(),
+	}
+
+	if !blockModel.Config.IsNull() {
+		if err := json.Unmarshal([]byte(blockModel.Config.ValueString()), &block.Config); err != nil {
+			diags.AddAttributeError(attrPath, "Invalid Block Config", fmt.Sprintf("The config of block %q must be a JSON object, e.g. built with jsonencode(), got error: %s", blockModel.Name.ValueString(), err))
+			return nil, diags
+		}
+	}
+
+	if !blockModel.Position.IsNull() {
+		var position FlowBlockPositionModel
+		diags.Append(blockModel.Position.As(ctx, &position, basetypes.ObjectAsOptions{})...)
+		block.Position = &flowDefinitionPosition{
+			X: position.X.ValueFloat64(),
+			Y: position.Y.ValueFloat64(),
+		}
+	}
+
+	var connections []FlowBlockConnectionModel
+	diags.Append(blockModel.Connections.ElementsAs(ctx, &connections, false)...)
+	for _, connection := range connections {
+		block.Connections = append(block.Connections, flowDefinitionConnection{
+			Output: connection.Output.ValueString(),
+			Target: connection.Target.ValueString(),
+		})
+	}
+
+	return block, diags
+}
+
+// flowBlocksFromDefinition converts a definition into a "block" attribute value.
+// Parts of the definition which aren't modeled by the provider are dropped.
+func flowBlocksFromDefinition(definition *flowDefinition) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elements := make([]attr.Value, 0, len(definition.Blocks))
+	for name, block := range definition.Blocks {
+		element, blockDiags := flowBlockValue(name, block)
+		diags.Append(blockDiags...)
+		if blockDiags.HasError() {
+			continue
+		}
+		elements = append(elements, element)
+	}
+
+	blocks, setDiags := types.SetValue(types.ObjectType{AttrTypes: flowBlockAttrTypes}, elements)
+	diags.Append(setDiags...)
+
+	return blocks, diags
+}
+
+// flowBlockValue converts a single block of a definition into its HCL representation.
+func flowBlockValue(name string, block *flowDefinitionBlock) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	config := types.StringNull()
+	if block.Config != nil {
+		configJSON, err := json.Marshal(block.Config)
+		if err != nil {
+			diags.AddError("Invalid Block Config", fmt.Sprintf("The config of block %q can't be represented as JSON, got error: %s", name, err))
+			return types.ObjectNull(flowBlockAttrTypes), diags
+		}
+		config = types.StringValue(string(configJSON))
+	}
+
+	position := types.ObjectNull(flowBlockPositionAttrTypes)
+	if block.Position != nil {
+		position = types.ObjectValueMust(flowBlockPositionAttrTypes, map[string]attr.Value{
+			"x": types.Float64Value(block.Position.X),
+			"y": types.Float64Value(block.Position.Y),
+		})
+	}
+
+	connections := types.ListNull(types.ObjectType{AttrTypes: flowBlockConnectionAttrTypes})
+	if len(block.Connections) > 0 {
+		connectionElements := make([]attr.Value, 0, len(block.Connections))
+		for _, connection := range block.Connections {
+			connectionElements = append(connectionElements, types.ObjectValueMust(flowBlockConnectionAttrTypes, map[string]attr.Value{
+				"output": optionalStringValue(connection.Output),
+				"target": types.StringValue(connection.Target),
+			}))
+		}
+		connections = types.ListValueMust(types.ObjectType{AttrTypes: flowBlockConnectionAttrTypes}, connectionElements)
+	}
+
+	return types.ObjectValueMust(flowBlockAttrTypes, map[string]attr.Value{
+		"name":                types.StringValue(name),
+		"type":                types.StringValue(block.Type),
+		"app":                 optionalStringValue(block.App),
+		"app_installation_id": optionalStringValue(block.AppInstallationID),
+		"config":              config,
+		"position":            position,
+		"connections":         connections,
+	}), diags
+}
+
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// rewriteIDs replaces every string value of the definition that equals one of the mapped IDs with the ID it's mapped to.
+func (d *flowDefinition) rewriteIDs(replacements map[string]string) {
+	for _, block := range d.Blocks {
+		if replacement, ok := replacements[block.AppInstallationID]; ok {
+			block.AppInstallationID = replacement
+		}
+		for key, value := range block.Config {
+			block.Config[key] = rewriteIDsInValue(value, replacements)
+		}
+		for key, value := range block.Other {
+			block.Other[key] = rewriteIDsInValue(value, replacements)
+		}
+	}
+	for key, value := range d.Other {
+		d.Other[key] = rewriteIDsInValue(value, replacements)
+	}
+}
+
+func rewriteIDsInValue(value any, replacements map[string]string) any {
+	switch value := value.(type) {
+	case string:
+		if replacement, ok := replacements[value]; ok {
+			return replacement
+		}
+		return value
+	case map[string]any:
+		for key, element := range value {
+			value[key] = rewriteIDsInValue(element, replacements)
+		}
+		return value
+	case []any:
+		for i, element := range value {
+			value[i] = rewriteIDsInValue(element, replacements)
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderFlowDefinitionRoundTrip(t *testing.T) {
+	variables := map[string]string{"channel": "#alerts: \"ops\""}
+
+	for name, test := range map[string]struct {
+		definition string
+		want       map[string]string
+	}{
+		"variable": {
+			definition: "name: \"{{ var.channel }}\"\n",
+			want:       map[string]string{"name": "#alerts: \"ops\""},
+		},
+		"escaped variable": {
+			definition: "name: \\{{ var.x }}\n",
+			want:       map[string]string{"name": "{{ var.x }}"},
+		},
+		"escaped backslash": {
+			definition: "name: \"\\\\\\\\{{ var.x }}\"\n",
+			want:       map[string]string{"name": "\\{{ var.x }}"},
+		},
+		"variable and escaped variable": {
+			definition: "name: \"prefix {{ var.channel }}\"\nother: '\\{{ var.x }}'\n",
+			want:       map[string]string{"name": "prefix #alerts: \"ops\"", "other": "{{ var.x }}"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rendered, err := renderFlowDefinition(test.definition, variables)
+			if err != nil {
+				t.Fatalf("rendering: %s", err)
+			}
+			assertFlowDefinitionValues(t, rendered, test.want)
+
+			// A rendered definition, e.g. an exported one, is escaped before being stored, and rendered again on refresh.
+			escaped, err := escapeFlowDefinitionVariables(rendered)
+			if err != nil {
+				t.Fatalf("escaping: %s", err)
+			}
+			rerendered, err := renderFlowDefinition(escaped, nil)
+			if err != nil {
+				t.Fatalf("rendering again: %s", err)
+			}
+			assertFlowDefinitionValues(t, rerendered, test.want)
+		})
+	}
+}
+
+func TestRenderFlowDefinitionUndefinedVariable(t *testing.T) {
+	_, err := renderFlowDefinition("name: \"{{ var.x }}\"\nother: \"{{ var.y }}\"\n", map[string]string{"y": "y"})
+	if err == nil || err.Error() != "the flow definition uses undefined variables: x" {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func assertFlowDefinitionValues(t *testing.T, definition string, want map[string]string) {
+	t.Helper()
+
+	var got map[string]string
+	if err := yaml.Unmarshal([]byte(definition), &got); err != nil {
+		t.Fatalf("parsing %q: %s", definition, err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s: got %q, want %q", key, got[key], value)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = FlowDefinitionType{}
+	_ basetypes.StringValuableWithSemanticEquals = FlowDefinitionValue{}
+)
+
+// FlowDefinitionType is a string type for YAML flow definitions,
+// which treats definitions differing only in formatting (key order, quoting, comments) as equal.
+type FlowDefinitionType struct {
+	basetypes.StringType
+}
+
+func (t FlowDefinitionType) String() string {
+	return "FlowDefinitionType"
+}
+
+func (t FlowDefinitionType) Equal(o attr.Type) bool {
+	other, ok := o.(FlowDefinitionType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t FlowDefinitionType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return FlowDefinitionValue{StringValue: in}, nil
+}
+
+func (t FlowDefinitionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t FlowDefinitionType) ValueType(ctx context.Context) attr.Value {
+	return FlowDefinitionValue{}
+}
+
+type FlowDefinitionValue struct {
+	basetypes.StringValue
+}
+
+func NewFlowDefinitionValue(definition string) FlowDefinitionValue {
+	return FlowDefinitionValue{StringValue: basetypes.NewStringValue(definition)}
+}
+
+func NewFlowDefinitionNull() FlowDefinitionValue {
+	return FlowDefinitionValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewFlowDefinitionUnknown() FlowDefinitionValue {
+	return FlowDefinitionValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v FlowDefinitionValue) Type(ctx context.Context) attr.Type {
+	return FlowDefinitionType{}
+}
+
+func (v FlowDefinitionValue) Equal(o attr.Value) bool {
+	other, ok := o.(FlowDefinitionValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v FlowDefinitionValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(FlowDefinitionValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return flowDefinitionsEqual(v.ValueString(), newValue.ValueString()), diags
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &FlowPromotionResource{}
+	_ resource.ResourceWithModifyPlan = &FlowPromotionResource{}
+)
+
+func NewFlowPromotionResource() resource.Resource {
+	return &FlowPromotionResource{}
+}
+
+type FlowPromotionResource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+type FlowPromotionResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	SourceFlowID             types.String `tfsdk:"source_flow_id"`
+	TargetFlowID             types.String `tfsdk:"target_flow_id"`
+	AppInstallationIDMapping types.Map    `tfsdk:"app_installation_id_mapping"`
+	DataTableIDMapping       types.Map    `tfsdk:"data_table_id_mapping"`
+	SecretIDMapping          types.Map    `tfsdk:"secret_id_mapping"`
+	Definition               types.String `tfsdk:"definition"`
+	ReadablePlan             types.String `tfsdk:"readable_plan"`
+	PlannedOperations        types.List   `tfsdk:"planned_operations"`
+}
+
+func (r *FlowPromotionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_promotion"
+}
+
+func (r *FlowPromotionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Promotes a flow from one project to another, e.g. from staging to production, by copying the source flow's definition to the target flow.
+
+References to app installations, data tables and secrets of the source project are rewritten using the given mappings. The target flow is updated whenever the source flow changes, and the plan shows the resulting changes to the target flow in "readable_plan". Deleting the promotion leaves the target flow as it is.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the promotion, which is the ID of the target flow.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_flow_id": schema.StringAttribute{
+				Description: "ID of the flow to promote.",
+				Required:    true,
+			},
+			"target_flow_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the flow to promote to, e.g. managed by a `flows_flow` resource with `drift_policy` set to `warn`, so that it doesn't revert the promoted definition.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_installation_id_mapping": schema.MapAttribute{
+				Description: "Mapping of app installation IDs in the source flow to the app installation IDs to use in the target flow. Every app installation used by the source flow must be mapped.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"data_table_id_mapping": schema.MapAttribute{
+				Description: "Mapping of data table IDs referenced by the source flow to the data table IDs to reference in the target flow.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"secret_id_mapping": schema.MapAttribute{
+				Description: "Mapping of secret IDs referenced by the source flow to the secret IDs to reference in the target flow.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "Definition applied to the target flow, i.e. the source flow's definition with its references rewritten.",
+				Computed:    true,
+			},
+			"readable_plan": schema.StringAttribute{
+				Description: "Human-readable description of the changes the promotion makes to the target flow.",
+				Computed:    true,
+			},
+			"planned_operations": schema.ListNestedAttribute{
+				Description:  "Operations planned to be performed on the target flow's blocks by the promotion.",
+				Computed:     true,
+				NestedObject: planOperationNestedObject(),
+			},
+		},
+	}
+}
+
+func (r *FlowPromotionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func (r *FlowPromotionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Promotion is planned to be deleted, nothing to do.
+		return
+	}
+
+	var plan FlowPromotionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.SourceFlowID.IsUnknown() || plan.TargetFlowID.IsUnknown() ||
+		isMapPartiallyUnknown(plan.AppInstallationIDMapping) || isMapPartiallyUnknown(plan.DataTableIDMapping) || isMapPartiallyUnknown(plan.SecretIDMapping) {
+		// The promotion is planned on apply instead.
+		return
+	}
+
+	definition, diags := r.promotedDefinition(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planChangesRes, err := CallFlowsAPI[PlanChangesRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_changes", PlanChangesRequest{
+		FlowID:     plan.TargetFlowID.ValueString(),
+		Definition: definition,
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("target_flow_id"), "Invalid Flow Promotion", "Unable to plan the promotion to the target flow, got error: "+err.Error())
+		return
+	}
+
+	if !req.State.Raw.IsNull() && len(planChangesRes.Plan.Operations) == 0 {
+		// The target flow is up to date, keep the computed attributes as they are.
+		var state FlowPromotionResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), state.Definition)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("readable_plan"), state.ReadablePlan)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("planned_operations"), state.PlannedOperations)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), definition)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("readable_plan"), types.StringPointerValue(planChangesRes.ReadablePlan))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("planned_operations"), plannedOperationsValue(planChangesRes.Plan.Operations))...)
+}
+
+func (r *FlowPromotionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FlowPromotionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.promote(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowPromotionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FlowPromotionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changes to the source or target flow are detected when planning, only the target flow's existence is checked here.
+	_, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](*r.providerData, "/provider/flows/get", GetFlowRequest{
+		FlowID: data.TargetFlowID.ValueString(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", "Unable to fetch target flow, got error: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowPromotionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FlowPromotionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.promote(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowPromotionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to do on delete, the target flow keeps the promoted definition.
+}
+
+// promote applies the planned definition to the target flow, computing whatever couldn't be planned.
+func (r *FlowPromotionResource) promote(data *FlowPromotionResourceModel, diags *diag.Diagnostics) {
+	if data.Definition.IsUnknown() {
+		definition, definitionDiags := r.promotedDefinition(*data)
+		diags.Append(definitionDiags...)
+		if diags.HasError() {
+			return
+		}
+		data.Definition = types.StringValue(definition)
+	}
+
+	if data.ReadablePlan.IsUnknown() || data.PlannedOperations.IsUnknown() {
+		planChangesRes, err := CallFlowsAPI[PlanChangesRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_changes", PlanChangesRequest{
+			FlowID:     data.TargetFlowID.ValueString(),
+			Definition: data.Definition.ValueString(),
+		})
+		if err != nil {
+			diags.AddError("Client Error", "Unable to plan the promotion to the target flow, got error: "+err.Error())
+			return
+		}
+		data.ReadablePlan = types.StringPointerValue(planChangesRes.ReadablePlan)
+		data.PlannedOperations = plannedOperationsValue(planChangesRes.Plan.Operations)
+	}
+
+	_, err := CallFlowsAPI[ApplyFlowConfigRequest, struct{}](*r.providerData, "/provider/flows/apply_config", ApplyFlowConfigRequest{
+		FlowID:     data.TargetFlowID.ValueString(),
+		Definition: data.Definition.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Client Error", "Unable to apply the promoted definition to the target flow, got error: "+err.Error())
+		return
+	}
+
+	data.ID = data.TargetFlowID
+}
+
+// promotedDefinition exports the source flow's definition and rewrites its references for the target flow.
+func (r *FlowPromotionResource) promotedDefinition(data FlowPromotionResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	exportRes, err := CallFlowsAPI[ExportFlowDefinitionRequest, ExportFlowDefinitionResponse](*r.providerData, "/provider/flows/export_definition", ExportFlowDefinitionRequest{
+		FlowID: data.SourceFlowID.ValueString(),
+	})
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_flow_id"), "Client Error", "Unable to export the source flow's definition, got error: "+err.Error())
+		return "", diags
+	}
+
+	definition, err := parseFlowDefinition(exportRes.Definition)
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_flow_id"), "Invalid Flow Definition", "Unable to parse the source flow's definition, got error: "+err.Error())
+		return "", diags
+	}
+
+	appInstallationIDs := getStringMap(data.AppInstallationIDMapping)
+	unmapped := make(map[string]struct{})
+	for _, block := range definition.Blocks {
+		if _, ok := appInstallationIDs[block.AppInstallationID]; !ok && block.AppInstallationID != "" {
+			unmapped[block.AppInstallationID] = struct{}{}
+		}
+	}
+	if len(unmapped) > 0 {
+		diags.AddAttributeError(
+			path.Root("app_installation_id_mapping"),
+			"Unmapped App Installations",
+			fmt.Sprintf("The source flow uses app installations which have no mapping to the target project: %s.", strings.Join(slices.Sorted(maps.Keys(unmapped)), ", ")),
+		)
+		return "", diags
+	}
+
+	replacements := make(map[string]string)
+	maps.Copy(replacements, appInstallationIDs)
+	maps.Copy(replacements, getStringMap(data.DataTableIDMapping))
+	maps.Copy(replacements, getStringMap(data.SecretIDMapping))
+	definition.rewriteIDs(replacements)
+
+	serialized, err := definition.canonical()
+	if err != nil {
+		diags.AddError("Invalid Flow Definition", err.Error())
+		return "", diags
+	}
+
+	return serialized, diags
+}
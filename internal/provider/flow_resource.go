@@ -2,23 +2,52 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &FlowResource{}
-	_ resource.ResourceWithModifyPlan  = &FlowResource{}
-	_ resource.ResourceWithImportState = &FlowResource{}
+	_ resource.Resource                     = &FlowResource{}
+	_ resource.ResourceWithModifyPlan       = &FlowResource{}
+	_ resource.ResourceWithImportState      = &FlowResource{}
+	_ resource.ResourceWithConfigValidators = &FlowResource{}
+)
+
+const (
+	onCreateFailureDelete = "delete"
+	onCreateFailureKeep   = "keep"
+)
+
+const (
+	managedBlocksAll     = "all"
+	managedBlocksDefined = "defined"
+)
+
+const (
+	driftPolicyOverwrite = "overwrite"
+	driftPolicyFail      = "fail"
+	driftPolicyWarn      = "warn"
 )
 
 func NewFlowResource() resource.Resource {
@@ -30,18 +59,57 @@ type FlowResource struct {
 }
 
 type FlowResourceModel struct {
-	ProjectId              types.String `tfsdk:"project_id"`
-	Id                     types.String `tfsdk:"id"`
-	Name                   types.String `tfsdk:"name"`
-	Definition             types.String `tfsdk:"definition"`
-	AppInstallationMapping types.Map    `tfsdk:"app_installation_mapping"`
-	Blocks                 types.Map    `tfsdk:"blocks"`
+	ProjectId              types.String        `tfsdk:"project_id"`
+	Id                     types.String        `tfsdk:"id"`
+	Name                   types.String        `tfsdk:"name"`
+	Definition             FlowDefinitionValue `tfsdk:"definition"`
+	Block                  types.Set           `tfsdk:"block"`
+	DefinitionVariables    types.Map           `tfsdk:"definition_variables"`
+	DefinitionDir          types.String        `tfsdk:"definition_dir"`
+	DefinitionFiles        types.List          `tfsdk:"definition_files"`
+	DefinitionSHA256       types.String        `tfsdk:"definition_sha256"`
+	AppInstallationMapping types.Map           `tfsdk:"app_installation_mapping"`
+	DataTableMapping       types.Map           `tfsdk:"data_table_mapping"`
+	SecretMapping          types.Map           `tfsdk:"secret_mapping"`
+	Blocks                 types.Map           `tfsdk:"blocks"`
+	PlannedOperations      types.List          `tfsdk:"planned_operations"`
+	OnCreateFailure        types.String        `tfsdk:"on_create_failure"`
+	Enabled                types.Bool          `tfsdk:"enabled"`
+	AllowProjectMove       types.Bool          `tfsdk:"allow_project_move"`
+	ManagedBlocks          types.String        `tfsdk:"managed_blocks"`
+	DriftPolicy            types.String        `tfsdk:"drift_policy"`
+	Drift                  types.List          `tfsdk:"drift"`
+	MarkAsManaged          types.Bool          `tfsdk:"mark_as_managed"`
+	ConfirmBlocks          types.Bool          `tfsdk:"confirm_blocks"`
+	WaitForReady           types.Bool          `tfsdk:"wait_for_ready"`
+	Revision               types.Int64         `tfsdk:"revision"`
+	RollbackToRevision     types.Int64         `tfsdk:"rollback_to_revision"`
+	Timeouts               timeouts.Value      `tfsdk:"timeouts"`
+}
+
+var plannedOperationAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"block_name":  types.StringType,
+	"field_paths": types.ListType{ElemType: types.StringType},
+	"before":      types.StringType,
+	"after":       types.StringType,
 }
 
 func (r *FlowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_flow"
 }
 
+func (r *FlowResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("definition"),
+			path.MatchRoot("block"),
+			path.MatchRoot("definition_dir"),
+			path.MatchRoot("definition_files"),
+		),
+	}
+}
+
 func (r *FlowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Creates and manages a Flow based on the provided definition in YAML format.
@@ -49,8 +117,25 @@ func (r *FlowResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 The easiest way to get started is to select a couple blocks through the Flows UI and then copy (via ctrl+c / cmd+c) them. You can then paste into a yaml file and use that as the definition.`,
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
-				Description: "ID of the project to create the flow in.",
-				Required:    true,
+				MarkdownDescription: "ID of the project to create the flow in. Changing it replaces the flow, unless `allow_project_move` is set.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var allowProjectMove types.Bool
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_project_move"), &allowProjectMove)...)
+							resp.RequiresReplace = !allowProjectMove.ValueBool()
+						},
+						"Replaces the flow when the project changes, unless allow_project_move is set.",
+						"Replaces the flow when the project changes, unless `allow_project_move` is set.",
+					),
+				},
+			},
+			"allow_project_move": schema.BoolAttribute{
+				MarkdownDescription: "Whether to move the flow to another project in place when `project_id` changes, instead of replacing it.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 			"id": schema.StringAttribute{
 				Description: "ID of the flow.",
@@ -64,20 +149,217 @@ The easiest way to get started is to select a couple blocks through the Flows UI
 				Required:    true,
 			},
 			"definition": schema.StringAttribute{
-				Description: "YAML definition of the flow, easiest to obtain by copying blocks from the Flows UI.",
-				Required:    true,
+				MarkdownDescription: "YAML definition of the flow, easiest to obtain by copying blocks from the Flows UI. Differences in formatting only, like key order, quoting or comments, are not considered changes. Exactly one of `definition`, `block`, `definition_dir` or `definition_files` must be set; when using one of the others, this holds the definition generated from it.",
+				CustomType:          FlowDefinitionType{},
+				Optional:            true,
+				Computed:            true,
+			},
+			"block": schema.SetNestedAttribute{
+				MarkdownDescription: "Blocks of the flow, as an alternative to a YAML `definition`, allowing for HCL expressions and references inside blocks.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: flowBlockSchemaAttributes(schema.StringAttribute{
+						Description: "Name of the block, unique within the flow.",
+						Required:    true,
+					}),
+				},
+			},
+			"definition_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory containing the definition split into multiple YAML files (`*.yaml` or `*.yml`, not recursive), which are merged into one definition. Each file holds a part of the definition in the usual format, and a block may only be defined in one of them. Relative paths are resolved against the working directory, so prefer paths based on `path.module`.",
+				Optional:            true,
+			},
+			"definition_files": schema.ListAttribute{
+				MarkdownDescription: "Like `definition_dir`, but with an explicit list of YAML files to merge.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"definition_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 hash of `definition`, changing whenever the definition does.",
+				Computed:            true,
+			},
+			"definition_variables": schema.MapAttribute{
+				MarkdownDescription: "Variables to substitute in the definition before applying it, e.g. to use the same definition across environments. A `{{ var.NAME }}` placeholder in a string value of the definition is replaced with the value of the `NAME` variable, and using a variable that isn't defined is an error. A value made of just a placeholder must be quoted, e.g. `channel: \"{{ var.channel }}\"`, as YAML would otherwise parse it as a mapping. Substituted values are always strings. To keep a literal placeholder, escape it with a backslash: `\\{{ var.NAME }}`. The escape is written as-is only in plain (unquoted) scalars: in double-quoted ones the backslash must itself be escaped, as `\\\\{{ var.NAME }}`. Drift is detected by comparing the flow against the rendered definition.",
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 			"app_installation_mapping": schema.MapAttribute{
-				Description: "Mapping of app keys to app installation IDs to use when applying the flow definition. Can be used to specify installation ids when they are not provided in the yaml, or to override them.",
+				Description: "Mapping of app keys to app installation IDs to use when applying the flow definition. Can be used to specify installation ids when they are not provided in the yaml, or to override them. Every key must be an app used by a block of the definition, and every installation must belong to the flow's project.",
 				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"data_table_mapping": schema.MapAttribute{
+				MarkdownDescription: "Mapping of keys to data table IDs, e.g. of `flows_data_table` resources, so that the IDs don't have to be hardcoded in the YAML. The definition references a data table with a `{{ data_table.KEY }}` placeholder in a string value. References without a mapping, and keys the definition doesn't reference, are reported at plan time.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"secret_mapping": schema.MapAttribute{
+				MarkdownDescription: "Mapping of keys to secret IDs, e.g. of `flows_secret` resources, so that the IDs don't have to be hardcoded in the YAML. The definition references a secret with a `{{ secret.KEY }}` placeholder in a string value. References without a mapping, and keys the definition doesn't reference, are reported at plan time.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"blocks": schema.MapAttribute{
-				Description: "Map of blocks in the flow, keyed by their names. Each block exposes its ID.",
+				Description: "Map of blocks in the flow, keyed by their names. Each block exposes its ID, type, app key and app installation ID, and for blocks receiving HTTP requests, like webhooks, their public URL.",
 				Computed:    true,
 				ElementType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"id": types.StringType,
+					AttrTypes: flowBlockDetailsAttrTypes,
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the flow is running. A new flow is only enabled or disabled once its definition has been applied, so it can be shipped disabled and enabled later, e.g. once the app installations it depends on are ready.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"managed_blocks": schema.StringAttribute{
+				MarkdownDescription: "Which blocks of the flow are managed by this resource. With `all` (the default), the flow contains exactly the blocks of the definition. With `defined`, only the blocks in the definition, and those removed from it, are managed, while other blocks are left alone and not considered drift, so that they can be owned elsewhere, e.g. by `flows_flow_block` resources.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(managedBlocksAll),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managedBlocksAll, managedBlocksDefined),
+				},
+			},
+			"on_create_failure": schema.StringAttribute{
+				MarkdownDescription: "What to do with a newly created flow if its definition can't be applied. `delete` (the default) removes the flow, so that creation is atomic, while `keep` leaves the empty flow in place for inspection; it will be replaced on the next apply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(onCreateFailureDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onCreateFailureDelete, onCreateFailureKeep),
+				},
+			},
+			"planned_operations": schema.ListNestedAttribute{
+				MarkdownDescription: "Operations planned to be performed on the flow's blocks by this change, e.g. for inspection by policy tools. Once applied, it holds the operations performed by the most recent change.",
+				Computed:            true,
+				NestedObject:        planOperationNestedObject(),
+			},
+			"drift_policy": schema.StringAttribute{
+				MarkdownDescription: "What to do when the flow has been changed outside of Terraform, e.g. in the Flows UI. `overwrite` (the default) reverts the changes on the next apply, `fail` blocks the plan with an error describing the changed blocks, and `warn` only shows a warning, keeping the changes until the definition or app installation mapping is changed.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(driftPolicyOverwrite),
+				Validators: []validator.String{
+					stringvalidator.OneOf(driftPolicyOverwrite, driftPolicyFail, driftPolicyWarn),
+				},
+			},
+			"drift": schema.ListNestedAttribute{
+				Description:  "Changes made to the flow outside of Terraform, as detected when it was last refreshed, described by the operations that would revert them.",
+				Computed:     true,
+				NestedObject: planOperationNestedObject(),
+			},
+			"revision": schema.Int64Attribute{
+				MarkdownDescription: "Revision recorded when the definition was last changed. Every apply changing the definition records a snapshot of the flow's exported definition as a new revision, listed by the `flows_flow_revisions` data source.",
+				Computed:            true,
+			},
+			"rollback_to_revision": schema.Int64Attribute{
+				MarkdownDescription: "Revision to roll the flow back to, applying its recorded definition instead of the configured one. Meant to be set temporarily, e.g. until a misbehaving definition is fixed; removing it applies the configured definition again.",
+				Optional:            true,
+			},
+			"confirm_blocks": schema.BoolAttribute{
+				MarkdownDescription: "Whether to confirm the entities of the flow's blocks which are left in draft after applying the definition, instead of confirming each of them with a `flows_entity_confirmation` resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait after applying the definition until the entities of the flow's blocks are ready, failing with the blocks that aren't. Blocks in draft only become ready once confirmed, e.g. with `confirm_blocks`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"mark_as_managed": schema.BoolAttribute{
+				Description: "Whether to mark the flow as managed by Terraform, so that the Flows UI shows it as locked.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: `How long to wait for the entities of the flow's blocks to be ready when "wait_for_ready" is true. Defaults to 5 minutes.`,
+				Update:            true,
+				UpdateDescription: `How long to wait for the entities of the flow's blocks to be ready when "wait_for_ready" is true. Defaults to 5 minutes.`,
+				Delete:            true,
+				DeleteDescription: "How long to wait for the flow and the entities of its blocks to be drained after deleting it. Defaults to 5 minutes.",
+			}),
+		},
+	}
+}
+
+// planOperationNestedObject describes a single operation of "planned_operations" or "drift".
+func planOperationNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Type of the operation, e.g. whether a block is being added, removed or reconfigured.",
+				Computed:    true,
+			},
+			"block_name": schema.StringAttribute{
+				Description: "Name of the block the operation applies to.",
+				Computed:    true,
+			},
+			"field_paths": schema.ListAttribute{
+				Description: "Paths of the block fields changed by the operation.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"before": schema.StringAttribute{
+				Description: "JSON-encoded value before the operation, if any.",
+				Computed:    true,
+			},
+			"after": schema.StringAttribute{
+				Description: "JSON-encoded value after the operation, if any.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// flowBlockSchemaAttributes returns the attributes describing a single block in HCL,
+// shared by the flow resource's "block" attribute and the flow block resource.
+func flowBlockSchemaAttributes(name schema.StringAttribute) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": name,
+		"type": schema.StringAttribute{
+			Description: "Type of the block, as defined by its app.",
+			Required:    true,
+		},
+		"app": schema.StringAttribute{
+			MarkdownDescription: "Key of the app providing the block. The installation to use can be set with `app_installation_id`, or with `app_installation_mapping` on the flow.",
+			Optional:            true,
+		},
+		"app_installation_id": schema.StringAttribute{
+			Description: "ID of the app installation providing the block.",
+			Optional:    true,
+		},
+		// Dynamic attributes are not supported inside collections, so the config is passed as JSON instead.
+		"config": schema.StringAttribute{
+			MarkdownDescription: "Configuration of the block as a JSON object, usually built with `jsonencode()`.",
+			Optional:            true,
+		},
+		"position": schema.SingleNestedAttribute{
+			Description: "Position of the block in the flow editor.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"x": schema.Float64Attribute{
+					Required: true,
+				},
+				"y": schema.Float64Attribute{
+					Required: true,
+				},
+			},
+		},
+		"connections": schema.ListNestedAttribute{
+			Description: "Connections from the outputs of the block to other blocks.",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"output": schema.StringAttribute{
+						Description: "Name of the output of this block. Uses the default output if not set.",
+						Optional:    true,
+					},
+					"target": schema.StringAttribute{
+						Description: "Name of the block to connect to.",
+						Required:    true,
 					},
 				},
 			},
@@ -99,6 +381,22 @@ func (r *FlowResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("planned_operations"), &data.PlannedOperations)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("on_create_failure"), &data.OnCreateFailure)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enabled"), &data.Enabled)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_project_move"), &data.AllowProjectMove)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("managed_blocks"), &data.ManagedBlocks)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("drift_policy"), &data.DriftPolicy)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mark_as_managed"), &data.MarkAsManaged)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("confirm_blocks"), &data.ConfirmBlocks)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("wait_for_ready"), &data.WaitForReady)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resolveDefinition(ctx, &data)...)
+	definition, diags := renderDefinition(data)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -112,30 +410,187 @@ func (r *FlowResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 	data.Id = types.StringValue(createFlowRes.Flow.ID)
+	plannedOperations := data.PlannedOperations
+	data.PlannedOperations = types.ListNull(types.ObjectType{AttrTypes: plannedOperationAttrTypes})
+	data.Drift = types.ListNull(types.ObjectType{AttrTypes: plannedOperationAttrTypes})
 	// Saving id, in case applying config fails.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
+	if plannedOperations.IsUnknown() {
+		// Not known at plan time, so we plan against the freshly created, empty flow.
+		plannedOperations, err = r.planOperations(createFlowRes.Flow.ID, definition, data, nil)
+		if err != nil {
+			r.handleCreateFailure(ctx, data, "Unable to plan flow changes, got error: "+err.Error(), resp)
+			return
+		}
+	}
+
 	_, err = CallFlowsAPI[ApplyFlowConfigRequest, struct{}](*r.providerData, "/provider/flows/apply_config", ApplyFlowConfigRequest{
 		FlowID:                 createFlowRes.Flow.ID,
-		Definition:             data.Definition.ValueString(),
+		Definition:             definition,
 		AppInstallationMapping: getAppInstallationMapping(data.AppInstallationMapping),
+		DataTableMapping:       getStringMap(data.DataTableMapping),
+		SecretMapping:          getStringMap(data.SecretMapping),
+	})
+	if err != nil {
+		r.handleCreateFailure(ctx, data, "Unable to apply flow definition, got error: "+err.Error(), resp)
+		return
+	}
+
+	data.Revision, err = r.recordRevision(createFlowRes.Flow.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to record flow revision, got error: "+err.Error())
+		return
+	}
+
+	// Only enable or disable the flow once its definition is in place.
+	_, err = CallFlowsAPI[SetFlowEnabledRequest, struct{}](*r.providerData, "/provider/flows/set_enabled", SetFlowEnabledRequest{
+		ID:      createFlowRes.Flow.ID,
+		Enabled: data.Enabled.ValueBool(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to apply flow definition, got error: "+err.Error())
+		resp.Diagnostics.AddError("Client Error", "Unable to set flow enabled state, got error: "+err.Error())
 		return
 	}
 
+	if data.MarkAsManaged.ValueBool() {
+		_, err = CallFlowsAPI[SetFlowManagedRequest, struct{}](*r.providerData, "/provider/flows/set_managed", SetFlowManagedRequest{
+			ID:      createFlowRes.Flow.ID,
+			Managed: true,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to mark flow as managed, got error: "+err.Error())
+			return
+		}
+	}
+
 	// Get the flow details including blocks
-	flowDetails, err := r.getFlowDetails(ctx, createFlowRes.Flow.ID)
+	flowDetails, err := getFlowDetails(ctx, *r.providerData, createFlowRes.Flow.ID)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to fetch flow details", err.Error())
 		return
 	}
-	data.Name = flowDetails.Name
-	data.Blocks = flowDetails.Blocks
+	flowDetails.applyTo(&data)
+	data.PlannedOperations = plannedOperations
+	data.Drift = plannedOperationsValue(nil)
+	data.DefinitionSHA256 = definitionSHA256Value(data.Definition)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.settleBlocks(ctx, data, definition, createTimeout, &resp.Diagnostics)
+}
+
+type CreateFlowRevisionRequest struct {
+	FlowID     string `json:"flowId"`
+	Definition string `json:"definition"`
+}
+
+type CreateFlowRevisionResponse struct {
+	Revision int64 `json:"revision"`
+}
+
+type GetFlowRevisionRequest struct {
+	FlowID   string `json:"flowId"`
+	Revision int64  `json:"revision"`
+}
+
+type GetFlowRevisionResponse struct {
+	Definition string `json:"definition"`
+}
+
+// recordRevision records a snapshot of the flow's exported definition as a new revision and returns its number.
+func (r *FlowResource) recordRevision(flowID string) (types.Int64, error) {
+	exportRes, err := CallFlowsAPI[ExportFlowDefinitionRequest, ExportFlowDefinitionResponse](*r.providerData, "/provider/flows/export_definition", ExportFlowDefinitionRequest{
+		FlowID: flowID,
+	})
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	revisionRes, err := CallFlowsAPI[CreateFlowRevisionRequest, CreateFlowRevisionResponse](*r.providerData, "/provider/flows/create_revision", CreateFlowRevisionRequest{
+		FlowID:     flowID,
+		Definition: exportRes.Definition,
+	})
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	return types.Int64Value(revisionRes.Revision), nil
+}
+
+// rollbackDefinition replaces the rendered definition with the definition of the revision set in "rollback_to_revision", if any.
+// The "definition" attribute itself is left alone, as its planned value has to match the configuration.
+func (r *FlowResource) rollbackDefinition(flowID string, rollbackToRevision types.Int64, definition *string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if rollbackToRevision.IsNull() || rollbackToRevision.IsUnknown() {
+		return diags
+	}
+
+	revisionRes, err := CallFlowsAPI[GetFlowRevisionRequest, GetFlowRevisionResponse](*r.providerData, "/provider/flows/get_revision", GetFlowRevisionRequest{
+		FlowID:   flowID,
+		Revision: rollbackToRevision.ValueInt64(),
+	})
+	if err != nil {
+		diags.AddAttributeError(path.Root("rollback_to_revision"), "Invalid Rollback", fmt.Sprintf("Unable to fetch revision %d of the flow, got error: %s", rollbackToRevision.ValueInt64(), err))
+		return diags
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("rollback_to_revision"),
+		"Flow Rolled Back",
+		fmt.Sprintf("The flow is rolled back to revision %d, ignoring the configured definition until \"rollback_to_revision\" is removed.", rollbackToRevision.ValueInt64()),
+	)
+
+	*definition = revisionRes.Definition
+
+	return diags
+}
+
+// settleBlocks confirms the entities of the blocks defined by the rendered definition and waits for them to be ready,
+// according to "confirm_blocks" and "wait_for_ready".
+func (r *FlowResource) settleBlocks(ctx context.Context, data FlowResourceModel, definition string, timeout time.Duration, dg *diag.Diagnostics) {
+	entityIDs := flowBlockEntityIDs(data.Blocks, definition)
+
+	if data.ConfirmBlocks.ValueBool() {
+		ConfirmFlowBlocks(ctx, *r.providerData, entityIDs, dg)
+		if dg.HasError() {
+			return
+		}
+	}
+
+	if data.WaitForReady.ValueBool() {
+		WaitForFlowBlocksReady(ctx, *r.providerData, entityIDs, timeout, dg)
+	}
+}
+
+// handleCreateFailure deals with a flow that was created, but whose definition couldn't be applied,
+// according to "on_create_failure".
+func (r *FlowResource) handleCreateFailure(ctx context.Context, data FlowResourceModel, detail string, resp *resource.CreateResponse) {
+	if data.OnCreateFailure.ValueString() == onCreateFailureKeep {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("%s\n\nThe empty flow %s has been kept, as \"on_create_failure\" is %q. It will be replaced on the next apply.", detail, data.Id.ValueString(), onCreateFailureKeep))
+		return
+	}
+
+	_, err := CallFlowsAPI[DeleteFlowRequest, struct{}](*r.providerData, "/provider/flows/delete", DeleteFlowRequest{
+		ID: data.Id.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("%s\n\nThe empty flow %s could not be deleted either, got error: %s. It will be replaced on the next apply.", detail, data.Id.ValueString(), err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+	resp.Diagnostics.AddError("Client Error", fmt.Sprintf("%s\n\nThe empty flow %s has been deleted, as \"on_create_failure\" is %q.", detail, data.Id.ValueString(), onCreateFailureDelete))
 }
 
 type CreateFlowRequest struct {
@@ -153,6 +608,10 @@ type ApplyFlowConfigRequest struct {
 	FlowID                 string            `json:"flowId"`
 	Definition             string            `json:"definition"`
 	AppInstallationMapping map[string]string `json:"appInstallationMapping,omitempty"`
+	DataTableMapping       map[string]string `json:"dataTableMapping,omitempty"`
+	SecretMapping          map[string]string `json:"secretMapping,omitempty"`
+	// OnlyBlocks limits the change to the listed blocks, leaving the others as they are. All blocks are changed when nil.
+	OnlyBlocks *[]string `json:"onlyBlocks,omitempty"`
 }
 
 type UpdateFlowRequest struct {
@@ -160,6 +619,21 @@ type UpdateFlowRequest struct {
 	Name string `json:"name"`
 }
 
+type MoveFlowRequest struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+}
+
+type SetFlowEnabledRequest struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+type SetFlowManagedRequest struct {
+	ID      string `json:"id"`
+	Managed bool   `json:"managed"`
+}
+
 type DeleteFlowRequest struct {
 	ID string `json:"id"`
 }
@@ -169,12 +643,19 @@ type GetFlowRequest struct {
 }
 
 type GetFlowResponse struct {
-	Name   string                  `json:"name"`
-	Blocks map[string]GetFlowBlock `json:"blocks"`
+	ProjectID *string                 `json:"projectId"`
+	Name      string                  `json:"name"`
+	Enabled   *bool                   `json:"enabled"`
+	Managed   *bool                   `json:"managed"`
+	Blocks    map[string]GetFlowBlock `json:"blocks"`
 }
 
 type GetFlowBlock struct {
-	ID string `json:"id"`
+	ID                string  `json:"id"`
+	Type              string  `json:"type"`
+	AppInstallationID *string `json:"appInstallationId,omitempty"`
+	AppKey            *string `json:"appKey,omitempty"`
+	PublicURL         *string `json:"publicUrl,omitempty"`
 }
 
 func (r *FlowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -190,7 +671,7 @@ func (r *FlowResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get the flow details including blocks
-	flowDetails, err := r.getFlowDetails(ctx, data.Id.ValueString())
+	flowDetails, err := getFlowDetails(ctx, *r.providerData, data.Id.ValueString())
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
@@ -199,19 +680,37 @@ func (r *FlowResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		resp.Diagnostics.AddError("Unable to fetch flow details", err.Error())
 		return
 	}
-	data.Name = flowDetails.Name
-	data.Blocks = flowDetails.Blocks
+	flowDetails.applyTo(&data)
 
+	// Compare against the rendered definition, or the revision it's rolled back to, as that's what has been applied.
+	definition, diags := r.appliedDefinition(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	onlyBlocks := managedBlockNames(data.ManagedBlocks, definition)
 	planResp, err := CallFlowsAPI[PlanChangesRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_changes", PlanChangesRequest{
 		FlowID:                 data.Id.ValueString(),
-		Definition:             data.Definition.ValueString(),
+		Definition:             definition,
 		AppInstallationMapping: getAppInstallationMapping(data.AppInstallationMapping),
+		DataTableMapping:       getStringMap(data.DataTableMapping),
+		SecretMapping:          getStringMap(data.SecretMapping),
+		OnlyBlocks:             onlyBlocks,
 	})
 	if err != nil && strings.Contains(err.Error(), "internal error") {
 		resp.Diagnostics.AddError("Client Error", "Unable to plan flow changes, got error: "+err.Error())
 		return
 	}
-	if planResp == nil || len(planResp.Plan.Operations) > 0 {
+	data.Drift = plannedOperationsValue(nil)
+	if planResp != nil {
+		data.Drift = plannedOperationsValue(planResp.Plan.Operations)
+	}
+
+	// Unless the drift is to be reverted, the applied definition is kept, so that the drift can be reported on plan.
+	driftPolicy := data.DriftPolicy.ValueString()
+	keepDefinition := driftPolicy == driftPolicyFail || driftPolicy == driftPolicyWarn
+	if planResp == nil || (len(planResp.Plan.Operations) > 0 && !keepDefinition) {
 		// Either the flow in the state is broken, or it semantically differs from what's on the server.
 		// Either way, we can take the definition from the backend.
 
@@ -222,8 +721,48 @@ func (r *FlowResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			resp.Diagnostics.AddError("Client Error", "Unable to fetch flow definition, got error: "+err.Error())
 			return
 		}
-		data.Definition = types.StringValue(exportRes.Definition)
+		// The exported definition is already rendered, so it mustn't be rendered again.
+		exportRes.Definition, err = escapeFlowDefinitionVariables(exportRes.Definition)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Flow Definition", "Unable to parse the exported flow definition, got error: "+err.Error())
+			return
+		}
+		data.Definition = NewFlowDefinitionValue(exportRes.Definition)
+
+		var exported *flowDefinition
+		if onlyBlocks != nil || !data.Block.IsNull() {
+			exported, err = parseFlowDefinition(exportRes.Definition)
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid Flow Definition", "Unable to parse the exported flow definition, got error: "+err.Error())
+				return
+			}
+		}
+
+		if onlyBlocks != nil {
+			// Blocks managed elsewhere aren't part of this flow's definition.
+			for name := range exported.Blocks {
+				if !slices.Contains(*onlyBlocks, name) {
+					delete(exported.Blocks, name)
+				}
+			}
+			serialized, err := exported.canonical()
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid Flow Definition", err.Error())
+				return
+			}
+			data.Definition = NewFlowDefinitionValue(serialized)
+		}
+
+		if !data.Block.IsNull() {
+			// Round-trip the definition into the blocks, so that the drift shows up there.
+			data.Block, diags = flowBlocksFromDefinition(exported)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 	}
+	data.DefinitionSHA256 = definitionSHA256Value(data.Definition)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -243,9 +782,13 @@ func (r *FlowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		return
 	}
 	if id.IsNull() || id.IsUnknown() {
-		// Flow not created yet, nothing to do.
-		// TODO: Ideally we'd have an endpoint to make a plan "from nothing" just to validate the definition is correct.
-		// If the definition and app installation mapping are known, of course.
+		if req.Plan.Raw.IsNull() {
+			// Flow is planned to be deleted, nothing to do.
+			return
+		}
+
+		// Flow not created yet, validate the definition by planning it against an empty flow.
+		r.planNewFlow(ctx, req, resp)
 		return
 	}
 	var plannedID types.String
@@ -266,18 +809,85 @@ func (r *FlowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 
 	var config FlowResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("drift_policy"), &config.DriftPolicy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.Drift.Elements()) > 0 {
+		switch config.DriftPolicy.ValueString() {
+		case driftPolicyFail:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("drift_policy"),
+				"Flow Changed Outside of Terraform",
+				fmt.Sprintf("Flow %s has been changed outside of Terraform, e.g. in the Flows UI. Reverting the changes would:\n%s\nEither undo the changes, or set \"drift_policy\" to %q to revert them.", data.Id.ValueString(), describeOperations(ctx, data.Drift), driftPolicyOverwrite),
+			)
+			return
+		case driftPolicyWarn:
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("drift_policy"),
+				"Flow Changed Outside of Terraform",
+				fmt.Sprintf("Flow %s has been changed outside of Terraform, e.g. in the Flows UI. Reverting the changes would:\n%s\nThe changes are kept until the definition or its mappings are changed, which reverts them.", data.Id.ValueString(), describeOperations(ctx, data.Drift)),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(resolveDefinition(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	if config.Definition.IsUnknown() {
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), config.Definition)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), types.StringUnknown())...)
+		return
+	}
+	if isMapPartiallyUnknown(config.DefinitionVariables) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_variables"), config.DefinitionVariables)...)
+		return
+	}
+
+	definition, diags := renderDefinition(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Validated against the configured definition, as a revision rolled back to has its references resolved.
+	resp.Diagnostics.Append(validateReferenceMappings(definition, config.DataTableMapping, config.SecretMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.RollbackToRevision.IsUnknown() {
 		return
 	}
+	resp.Diagnostics.Append(r.rollbackDefinition(data.Id.ValueString(), config.RollbackToRevision, &definition)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if config.AppInstallationMapping.IsUnknown() {
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("app_installation_mapping"), config.AppInstallationMapping)...)
 		return
 	}
+	if isMapPartiallyUnknown(config.DataTableMapping) || isMapPartiallyUnknown(config.SecretMapping) {
+		// Data tables and secrets are often created in the same run, so the changes are planned once their IDs are known.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data_table_mapping"), config.DataTableMapping)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_mapping"), config.SecretMapping)...)
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("managed_blocks"), &config.ManagedBlocks)...)
+	priorDefinition, diags := r.appliedDefinition(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateAppInstallationMapping(definition, config.ProjectId, config.AppInstallationMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Now, with both the definition and app installation mapping known,
 	// we make a plan with the definition from *the config*, as it's now available.
@@ -286,46 +896,338 @@ func (r *FlowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 
 	planChangesRes, err := CallFlowsAPI[PlanChangesRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_changes", PlanChangesRequest{
 		FlowID:                 data.Id.ValueString(),
-		Definition:             config.Definition.ValueString(),
+		Definition:             definition,
 		AppInstallationMapping: getAppInstallationMapping(config.AppInstallationMapping),
+		DataTableMapping:       getStringMap(config.DataTableMapping),
+		SecretMapping:          getStringMap(config.SecretMapping),
+		OnlyBlocks:             managedBlockNames(config.ManagedBlocks, definition, priorDefinition),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", "Unable to plan flow changes, got error: "+err.Error())
 		return
 	}
 
-	if len(planChangesRes.Plan.Operations) == 0 {
+	operations := planChangesRes.Plan.Operations
+	if keepsDrift(config.DriftPolicy, definition, priorDefinition, config, data) {
+		// The only changes are the ones made outside of Terraform, which are kept.
+		operations = nil
+	}
+
+	if len(operations) == 0 {
 		// Only set COMPUTED attributes from state. Leave config-provided attributes alone.
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("blocks"), data.Blocks)...)
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), data.Definition)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), definitionSHA256Value(data.Definition))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("planned_operations"), data.PlannedOperations)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("drift"), data.Drift)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision"), data.Revision)...)
 	} else {
 		// There are changes, set the planned state to the config.
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), config.Definition)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), definitionSHA256Value(config.Definition))...)
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("app_installation_mapping"), config.AppInstallationMapping)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("data_table_mapping"), config.DataTableMapping)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_mapping"), config.SecretMapping)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_variables"), config.DefinitionVariables)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("planned_operations"), plannedOperationsValue(operations))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("drift"), plannedOperationsValue(nil))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision"), types.Int64Unknown())...)
+	}
+
+	return
+}
 
-		if planChangesRes.ReadablePlan != nil {
-			resp.Diagnostics.AddWarning("Flow Changes Planned", *planChangesRes.ReadablePlan)
+// planNewFlow makes a plan "from nothing" for a flow that doesn't exist yet,
+// so that a broken definition or app installation mapping is reported at plan time,
+// instead of after the flow has already been created.
+func (r *FlowResource) planNewFlow(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var config FlowResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.RollbackToRevision.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("rollback_to_revision"), "Invalid Rollback", "A new flow has no revisions to roll back to.")
+		return
+	}
+	resp.Diagnostics.Append(resolveDefinition(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), config.Definition)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), definitionSHA256Value(config.Definition))...)
+
+	if config.Definition.IsUnknown() || isMapPartiallyUnknown(config.DefinitionVariables) {
+		// Can't validate until everything is known, the definition will be validated on apply.
+		return
+	}
+
+	definition, diags := renderDefinition(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateReferenceMappings(definition, config.DataTableMapping, config.SecretMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ProjectId.IsUnknown() || isMapPartiallyUnknown(config.AppInstallationMapping) ||
+		isMapPartiallyUnknown(config.DataTableMapping) || isMapPartiallyUnknown(config.SecretMapping) {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateAppInstallationMapping(definition, config.ProjectId, config.AppInstallationMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planChangesRes, err := CallFlowsAPI[PlanNewFlowRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_new_flow", PlanNewFlowRequest{
+		ProjectID:              config.ProjectId.ValueString(),
+		Definition:             definition,
+		AppInstallationMapping: getAppInstallationMapping(config.AppInstallationMapping),
+		DataTableMapping:       getStringMap(config.DataTableMapping),
+		SecretMapping:          getStringMap(config.SecretMapping),
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Flow Definition", "Unable to plan flow definition, got error: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("planned_operations"), plannedOperationsValue(planChangesRes.Plan.Operations))...)
+}
+
+// validateAppInstallationMapping checks the app installation mapping against the apps used by the blocks of the rendered definition,
+// and that the mapped app installations exist in the flow's project.
+func (r *FlowResource) validateAppInstallationMapping(definition string, projectID types.String, appInstallationMapping types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	parsed, err := parseFlowDefinition(definition)
+	if err != nil {
+		// Reported when planning the definition.
+		return diags
+	}
+	mapping := getAppInstallationMapping(appInstallationMapping)
+
+	usedApps := make(map[string]struct{})
+	for _, name := range slices.Sorted(maps.Keys(parsed.Blocks)) {
+		block := parsed.Blocks[name]
+		if block.App == "" {
+			continue
+		}
+		usedApps[block.App] = struct{}{}
+
+		if _, ok := mapping[block.App]; !ok && block.AppInstallationID == "" {
+			diags.AddAttributeWarning(
+				path.Root("app_installation_mapping"),
+				"Missing App Installation",
+				fmt.Sprintf("Block %q uses the app %q, which has neither an entry in \"app_installation_mapping\" nor an inline \"appInstallationId\".", name, block.App),
+			)
 		}
 	}
 
-	return
+	for _, app := range slices.Sorted(maps.Keys(mapping)) {
+		mappingPath := path.Root("app_installation_mapping").AtMapKey(app)
+		if _, ok := usedApps[app]; !ok {
+			detail := fmt.Sprintf("No block of the definition uses the app %q.", app)
+			if len(usedApps) > 0 {
+				detail += fmt.Sprintf(" The apps used are: %s.", strings.Join(slices.Sorted(maps.Keys(usedApps)), ", "))
+			}
+			diags.AddAttributeError(mappingPath, "Unused App Installation Mapping", detail)
+			continue
+		}
+
+		appInstallation, err := CallFlowsAPI[GetAppInstallationRequest, GetAppInstallationResponse](*r.providerData, getAppInstallationPath, GetAppInstallationRequest{
+			ID: mapping[app],
+		})
+		if err != nil {
+			if err.Error() == "not found" {
+				diags.AddAttributeError(mappingPath, "App Installation Not Found", fmt.Sprintf("The app installation %s mapped to the app %q does not exist.", mapping[app], app))
+				continue
+			}
+			diags.AddAttributeError(mappingPath, "Client Error", "Unable to read app installation, got error: "+err.Error())
+			continue
+		}
+		if !projectID.IsUnknown() && appInstallation.ProjectID != projectID.ValueString() {
+			diags.AddAttributeError(
+				mappingPath,
+				"App Installation in Another Project",
+				fmt.Sprintf("The app installation %s mapped to the app %q belongs to the project %s, not to the flow's project %s.", mapping[app], app, appInstallation.ProjectID, projectID.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateReferenceMappings checks the data table and secret mappings against the references in the rendered definition.
+// Only the keys of the mappings are checked, so that they can be validated before the IDs of new data tables and secrets are known.
+func validateReferenceMappings(definition string, dataTableMapping types.Map, secretMapping types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	references, err := flowDefinitionReferences(definition)
+	if err != nil {
+		// Reported when planning the definition.
+		return diags
+	}
+
+	for _, mapping := range []struct {
+		kind      string
+		noun      string
+		title     string
+		attribute string
+		value     types.Map
+	}{
+		{flowDefinitionReferenceDataTable, "data table", "Data Table", "data_table_mapping", dataTableMapping},
+		{flowDefinitionReferenceSecret, "secret", "Secret", "secret_mapping", secretMapping},
+	} {
+		if mapping.value.IsUnknown() {
+			continue
+		}
+		referenced := references[mapping.kind]
+		mapped := mapping.value.Elements()
+
+		for _, key := range slices.Sorted(maps.Keys(referenced)) {
+			if _, ok := mapped[key]; !ok {
+				diags.AddAttributeError(
+					path.Root(mapping.attribute),
+					"Missing "+mapping.title+" Mapping",
+					fmt.Sprintf("The definition references the %s %q with \"{{ %s.%s }}\", which has no entry in %q.", mapping.noun, key, mapping.kind, key, mapping.attribute),
+				)
+			}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(mapped)) {
+			if _, ok := referenced[key]; !ok {
+				detail := fmt.Sprintf("The definition doesn't reference the %s %q.", mapping.noun, key)
+				if len(referenced) > 0 {
+					detail += fmt.Sprintf(" The %ss referenced are: %s.", mapping.noun, strings.Join(slices.Sorted(maps.Keys(referenced)), ", "))
+				}
+				diags.AddAttributeError(path.Root(mapping.attribute).AtMapKey(key), "Unused "+mapping.title+" Mapping", detail)
+			}
+		}
+	}
+
+	return diags
+}
+
+type PlanNewFlowRequest struct {
+	ProjectID              string            `json:"projectId"`
+	Definition             string            `json:"definition"`
+	AppInstallationMapping map[string]string `json:"appInstallationMapping,omitempty"`
+	DataTableMapping       map[string]string `json:"dataTableMapping,omitempty"`
+	SecretMapping          map[string]string `json:"secretMapping,omitempty"`
 }
 
 type PlanChangesRequest struct {
 	FlowID                 string            `json:"flowId"`
 	Definition             string            `json:"definition"`
 	AppInstallationMapping map[string]string `json:"appInstallationMapping,omitempty"`
+	DataTableMapping       map[string]string `json:"dataTableMapping,omitempty"`
+	SecretMapping          map[string]string `json:"secretMapping,omitempty"`
+	// OnlyBlocks limits the plan to the listed blocks, like in ApplyFlowConfigRequest.
+	OnlyBlocks *[]string `json:"onlyBlocks,omitempty"`
 }
 
 type PlanChangesResponse struct {
 	Plan struct {
-		Operations []struct {
-			Type string `json:"type"`
-		} `json:"operations"`
+		Operations []PlanOperation `json:"operations"`
 	} `json:"plan"`
 	ReadablePlan *string `json:"readablePlan,omitempty"`
 }
 
+type PlanOperation struct {
+	Type       string          `json:"type"`
+	BlockName  string          `json:"blockName"`
+	FieldPaths []string        `json:"fieldPaths"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+}
+
+// planOperations plans the definition with the mappings of the given model against the flow and returns the resulting operations as a "planned_operations" value.
+func (r *FlowResource) planOperations(flowID string, definition string, mappings FlowResourceModel, onlyBlocks *[]string) (types.List, error) {
+	planChangesRes, err := CallFlowsAPI[PlanChangesRequest, PlanChangesResponse](*r.providerData, "/provider/flows/plan_changes", PlanChangesRequest{
+		FlowID:                 flowID,
+		Definition:             definition,
+		AppInstallationMapping: getAppInstallationMapping(mappings.AppInstallationMapping),
+		DataTableMapping:       getStringMap(mappings.DataTableMapping),
+		SecretMapping:          getStringMap(mappings.SecretMapping),
+		OnlyBlocks:             onlyBlocks,
+	})
+	if err != nil {
+		return types.ListNull(types.ObjectType{AttrTypes: plannedOperationAttrTypes}), err
+	}
+
+	return plannedOperationsValue(planChangesRes.Plan.Operations), nil
+}
+
+func plannedOperationsValue(operations []PlanOperation) types.List {
+	elements := make([]attr.Value, 0, len(operations))
+	for _, operation := range operations {
+		fieldPaths := make([]attr.Value, 0, len(operation.FieldPaths))
+		for _, fieldPath := range operation.FieldPaths {
+			fieldPaths = append(fieldPaths, types.StringValue(fieldPath))
+		}
+
+		elements = append(elements, types.ObjectValueMust(plannedOperationAttrTypes, map[string]attr.Value{
+			"type":        types.StringValue(operation.Type),
+			"block_name":  types.StringValue(operation.BlockName),
+			"field_paths": types.ListValueMust(types.StringType, fieldPaths),
+			"before":      rawJSONValue(operation.Before),
+			"after":       rawJSONValue(operation.After),
+		}))
+	}
+
+	return types.ListValueMust(types.ObjectType{AttrTypes: plannedOperationAttrTypes}, elements)
+}
+
+type PlanOperationModel struct {
+	Type       types.String `tfsdk:"type"`
+	BlockName  types.String `tfsdk:"block_name"`
+	FieldPaths types.List   `tfsdk:"field_paths"`
+	Before     types.String `tfsdk:"before"`
+	After      types.String `tfsdk:"after"`
+}
+
+// describeOperations lists the operations of a "planned_operations" or "drift" value, one per line.
+func describeOperations(ctx context.Context, operations types.List) string {
+	var operationModels []PlanOperationModel
+	if diags := operations.ElementsAs(ctx, &operationModels, false); diags.HasError() {
+		return ""
+	}
+
+	var description strings.Builder
+	for _, operation := range operationModels {
+		fmt.Fprintf(&description, "  - %s block %q", operation.Type.ValueString(), operation.BlockName.ValueString())
+
+		var fieldPaths []string
+		operation.FieldPaths.ElementsAs(ctx, &fieldPaths, false)
+		if len(fieldPaths) > 0 {
+			fmt.Fprintf(&description, " (%s)", strings.Join(fieldPaths, ", "))
+		}
+		description.WriteString("\n")
+	}
+
+	return description.String()
+}
+
+// keepsDrift reports whether changes made to the flow outside of Terraform are to be kept,
+// as "drift_policy" is "warn" and neither the definition nor its mappings changed.
+func keepsDrift(driftPolicy types.String, definition string, priorDefinition string, mappings FlowResourceModel, priorMappings FlowResourceModel) bool {
+	return driftPolicy.ValueString() == driftPolicyWarn &&
+		flowDefinitionsEqual(definition, priorDefinition) &&
+		mappings.AppInstallationMapping.Equal(priorMappings.AppInstallationMapping) &&
+		mappings.DataTableMapping.Equal(priorMappings.DataTableMapping) &&
+		mappings.SecretMapping.Equal(priorMappings.SecretMapping)
+}
+
+func rawJSONValue(raw json.RawMessage) types.String {
+	if len(raw) == 0 || string(raw) == "null" {
+		return types.StringNull()
+	}
+	return types.StringValue(string(raw))
+}
+
 func (r *FlowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data FlowResourceModel
 	// Read Terraform prior state data into the model
@@ -333,24 +1235,122 @@ func (r *FlowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	var config FlowResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resolveDefinition(ctx, &config)...)
+
+	var plannedDefinition FlowDefinitionValue
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("definition"), &plannedDefinition)...)
+
+	var plannedOperations types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("planned_operations"), &plannedOperations)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("on_create_failure"), &data.OnCreateFailure)...)
+
+	var plannedRevision types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("revision"), &plannedRevision)...)
+
+	var enabled types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enabled"), &enabled)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_project_move"), &data.AllowProjectMove)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("managed_blocks"), &data.ManagedBlocks)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("drift_policy"), &data.DriftPolicy)...)
+
+	var markAsManaged types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mark_as_managed"), &markAsManaged)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("confirm_blocks"), &data.ConfirmBlocks)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("wait_for_ready"), &data.WaitForReady)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := CallFlowsAPI[ApplyFlowConfigRequest, struct{}](*r.providerData, "/provider/flows/apply_config", ApplyFlowConfigRequest{
-		FlowID:                 data.Id.ValueString(),
-		Definition:             config.Definition.ValueString(),
-		AppInstallationMapping: getAppInstallationMapping(config.AppInstallationMapping),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", "Unable to apply flow definition, got error: "+err.Error())
+	definition, diags := renderDefinition(config)
+	resp.Diagnostics.Append(diags...)
+	priorDefinition, priorDiags := r.appliedDefinition(data)
+	resp.Diagnostics.Append(priorDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.rollbackDefinition(data.Id.ValueString(), config.RollbackToRevision, &definition)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Blocks removed from the definition are still managed, so that they get deleted.
+	onlyBlocks := managedBlockNames(data.ManagedBlocks, definition, priorDefinition)
+
+	if !config.ProjectId.Equal(data.ProjectId) {
+		// Only planned as an update when "allow_project_move" is set, otherwise the flow is replaced.
+		// The flow is moved first, so that the definition is applied in the context of the new project.
+		_, err := CallFlowsAPI[MoveFlowRequest, struct{}](*r.providerData, "/provider/flows/move", MoveFlowRequest{
+			ID:        data.Id.ValueString(),
+			ProjectID: config.ProjectId.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to move flow to another project, got error: "+err.Error())
+			return
+		}
+		data.ProjectId = config.ProjectId
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+
+	var err error
+	if keepsDrift(data.DriftPolicy, definition, priorDefinition, config, data) {
+		// Applying the unchanged definition would revert the changes made outside of Terraform.
+		if plannedOperations.IsUnknown() {
+			plannedOperations = plannedOperationsValue(nil)
+		}
+	} else {
+		if plannedOperations.IsUnknown() {
+			// Not known at plan time, so we plan the changes right before applying them.
+			plannedOperations, err = r.planOperations(data.Id.ValueString(), definition, config, onlyBlocks)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", "Unable to plan flow changes, got error: "+err.Error())
+				return
+			}
+		}
+
+		_, err = CallFlowsAPI[ApplyFlowConfigRequest, struct{}](*r.providerData, "/provider/flows/apply_config", ApplyFlowConfigRequest{
+			FlowID:                 data.Id.ValueString(),
+			Definition:             definition,
+			AppInstallationMapping: getAppInstallationMapping(config.AppInstallationMapping),
+			DataTableMapping:       getStringMap(config.DataTableMapping),
+			SecretMapping:          getStringMap(config.SecretMapping),
+			OnlyBlocks:             onlyBlocks,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to apply flow definition, got error: "+err.Error())
+			return
+		}
+		data.Drift = plannedOperationsValue(nil)
+
+		if plannedRevision.IsUnknown() && !flowDefinitionsEqual(definition, priorDefinition) {
+			// The applied definition changed, so it's recorded as a new revision.
+			// The planned revision is also unknown when only e.g. the name or a mapping isn't known at plan time.
+			data.Revision, err = r.recordRevision(data.Id.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", "Unable to record flow revision, got error: "+err.Error())
+				return
+			}
+		}
+	}
+	if !plannedRevision.IsUnknown() {
+		data.Revision = plannedRevision
+	}
 
-	data.ProjectId = config.ProjectId
 	data.Definition = config.Definition
+	if !plannedDefinition.IsUnknown() {
+		// Semantically equal to the applied one, but might be formatted differently.
+		data.Definition = plannedDefinition
+	}
+	data.Block = config.Block
+	data.DefinitionDir = config.DefinitionDir
+	data.DefinitionFiles = config.DefinitionFiles
+	data.DefinitionSHA256 = definitionSHA256Value(data.Definition)
+	data.DefinitionVariables = config.DefinitionVariables
+	data.RollbackToRevision = config.RollbackToRevision
 	data.AppInstallationMapping = config.AppInstallationMapping
+	data.DataTableMapping = config.DataTableMapping
+	data.SecretMapping = config.SecretMapping
+	data.PlannedOperations = plannedOperations
 
 	// Update flow name if changed
 	if !config.Name.Equal(data.Name) {
@@ -365,17 +1365,51 @@ func (r *FlowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.Name = config.Name
 	}
 
+	if !enabled.Equal(data.Enabled) {
+		_, err = CallFlowsAPI[SetFlowEnabledRequest, struct{}](*r.providerData, "/provider/flows/set_enabled", SetFlowEnabledRequest{
+			ID:      data.Id.ValueString(),
+			Enabled: enabled.ValueBool(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to set flow enabled state, got error: "+err.Error())
+			return
+		}
+		data.Enabled = enabled
+	}
+
+	if !markAsManaged.Equal(data.MarkAsManaged) {
+		_, err = CallFlowsAPI[SetFlowManagedRequest, struct{}](*r.providerData, "/provider/flows/set_managed", SetFlowManagedRequest{
+			ID:      data.Id.ValueString(),
+			Managed: markAsManaged.ValueBool(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Unable to mark flow as managed, got error: "+err.Error())
+			return
+		}
+		data.MarkAsManaged = markAsManaged
+	}
+
 	// Get the flow details including blocks
-	flowDetails, err := r.getFlowDetails(ctx, data.Id.ValueString())
+	flowDetails, err := getFlowDetails(ctx, *r.providerData, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to fetch flow details", err.Error())
 		return
 	}
-	data.Name = flowDetails.Name
-	data.Blocks = flowDetails.Blocks
+	flowDetails.applyTo(&data)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.settleBlocks(ctx, data, definition, updateTimeout, &resp.Diagnostics)
 }
 
 func (r *FlowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -388,6 +1422,12 @@ func (r *FlowResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultPollTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the flow
 	_, err := CallFlowsAPI[DeleteFlowRequest, struct{}](*r.providerData, "/provider/flows/delete", DeleteFlowRequest{
 		ID: data.Id.ValueString(),
@@ -396,21 +1436,45 @@ func (r *FlowResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete flow, got error: %s", err))
 		return
 	}
+
+	WaitForFlowDeleted(ctx, *r.providerData, data.Id.ValueString(), flowBlockEntityIDs(data.Blocks, ""), deleteTimeout, &resp.Diagnostics)
 }
 
 func (r *FlowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	flowID := req.ID
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), flowID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_create_failure"), onCreateFailureDelete)...)
 
 	// Fetch flow details (name, blocks)
-	flowDetails, err := r.getFlowDetails(ctx, flowID)
+	flowDetails, err := getFlowDetails(ctx, *r.providerData, flowID)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to fetch flow details", err.Error())
 		return
 	}
+	if flowDetails.ProjectID.IsNull() {
+		resp.Diagnostics.AddError("Unable to fetch flow details", fmt.Sprintf("The project of flow %q was not reported by the Flows API.", flowID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), flowDetails.ProjectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_project_move"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("managed_blocks"), managedBlocksAll)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("drift_policy"), driftPolicyOverwrite)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("confirm_blocks"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_ready"), false)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), flowDetails.Name)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("blocks"), flowDetails.Blocks)...)
+	enabled := flowDetails.Enabled
+	if enabled.IsNull() {
+		// There's no prior state to keep, so assume the default.
+		enabled = types.BoolValue(true)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enabled"), enabled)...)
+	markAsManaged := flowDetails.Managed
+	if markAsManaged.IsNull() {
+		markAsManaged = types.BoolValue(false)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mark_as_managed"), markAsManaged)...)
 
 	// Export definition from backend
 	exportRes, err := CallFlowsAPI[ExportFlowDefinitionRequest, ExportFlowDefinitionResponse](*r.providerData, "/provider/flows/export_definition", ExportFlowDefinitionRequest{
@@ -420,10 +1484,150 @@ func (r *FlowResource) ImportState(ctx context.Context, req resource.ImportState
 		resp.Diagnostics.AddError("Unable to export flow definition", err.Error())
 		return
 	}
+	// The exported definition is already rendered, so it mustn't be rendered again.
+	exportRes.Definition, err = escapeFlowDefinitionVariables(exportRes.Definition)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Flow Definition", "Unable to parse the exported flow definition, got error: "+err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("definition"), exportRes.Definition)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("definition_sha256"), flowDefinitionSHA256(exportRes.Definition))...)
+}
+
+// resolveDefinition sets the definition from the "block", "definition_dir" or "definition_files" attribute,
+// when one of those is used instead of "definition".
+func resolveDefinition(ctx context.Context, data *FlowResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var definition *flowDefinition
+	var sourcePath path.Path
+	var err error
+
+	switch {
+	case !data.Block.IsNull():
+		sourcePath = path.Root("block")
+		if !isFullyKnown(ctx, data.Block) {
+			data.Definition = NewFlowDefinitionUnknown()
+			return nil
+		}
+		definition, diags = flowDefinitionFromBlocks(ctx, data.Block)
+	case !data.DefinitionDir.IsNull():
+		sourcePath = path.Root("definition_dir")
+		if data.DefinitionDir.IsUnknown() {
+			data.Definition = NewFlowDefinitionUnknown()
+			return nil
+		}
+		var files []string
+		files, err = listFlowDefinitionDir(data.DefinitionDir.ValueString())
+		if err == nil {
+			definition, err = loadFlowDefinitionFiles(files)
+		}
+	case !data.DefinitionFiles.IsNull():
+		sourcePath = path.Root("definition_files")
+		if !isFullyKnown(ctx, data.DefinitionFiles) {
+			data.Definition = NewFlowDefinitionUnknown()
+			return nil
+		}
+		var files []string
+		diags.Append(data.DefinitionFiles.ElementsAs(ctx, &files, false)...)
+		if !diags.HasError() {
+			definition, err = loadFlowDefinitionFiles(files)
+		}
+	default:
+		// The definition is set directly.
+		return nil
+	}
+	if err != nil {
+		diags.AddAttributeError(sourcePath, "Invalid Flow Definition", err.Error())
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	serialized, err := definition.canonical()
+	if err != nil {
+		diags.AddAttributeError(sourcePath, "Invalid Flow Definition", err.Error())
+		return diags
+	}
+	data.Definition = NewFlowDefinitionValue(serialized)
+
+	return diags
+}
+
+// definitionSHA256Value returns the "definition_sha256" value matching the definition.
+func definitionSHA256Value(definition FlowDefinitionValue) types.String {
+	if definition.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if definition.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(flowDefinitionSHA256(definition.ValueString()))
+}
+
+// renderDefinition returns the definition as it's sent to the API, with its variables substituted.
+func renderDefinition(data FlowResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	rendered, err := renderFlowDefinition(data.Definition.ValueString(), getStringMap(data.DefinitionVariables))
+	if err != nil {
+		diags.AddAttributeError(path.Root("definition_variables"), "Invalid Flow Definition", err.Error())
+	}
+
+	return rendered, diags
+}
+
+// appliedDefinition returns the definition of the prior state as it has been applied to the flow,
+// i.e. the definition of the revision it's rolled back to, if any.
+func (r *FlowResource) appliedDefinition(data FlowResourceModel) (string, diag.Diagnostics) {
+	definition, diags := renderDefinition(data)
+	if diags.HasError() {
+		return definition, diags
+	}
+
+	// The rollback has already been reported when planning it.
+	diags.Append(r.rollbackDefinition(data.Id.ValueString(), data.RollbackToRevision, &definition).Errors()...)
+
+	return definition, diags
+}
+
+// managedBlockNames returns the names of the blocks defined by any of the rendered definitions when only those are managed,
+// according to "managed_blocks", or nil when all blocks of the flow are managed.
+func managedBlockNames(managedBlocks types.String, definitions ...string) *[]string {
+	if managedBlocks.ValueString() != managedBlocksDefined {
+		return nil
+	}
+
+	names := []string{}
+	for _, definition := range definitions {
+		// Definitions that can't be parsed are rejected by the API anyway.
+		parsed, err := parseFlowDefinition(definition)
+		if err != nil {
+			continue
+		}
+		for name := range parsed.Blocks {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return &names
+}
+
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return false
+	}
+	return terraformValue.IsFullyKnown()
 }
 
 func getAppInstallationMapping(m types.Map) map[string]string {
+	return getStringMap(m)
+}
+
+func getStringMap(m types.Map) map[string]string {
 	if m.IsNull() || m.IsUnknown() {
 		return nil
 	}
@@ -437,13 +1641,53 @@ func getAppInstallationMapping(m types.Map) map[string]string {
 	return result
 }
 
+// isMapPartiallyUnknown reports whether the map itself or any of its elements is unknown.
+func isMapPartiallyUnknown(m types.Map) bool {
+	if m.IsUnknown() {
+		return true
+	}
+	for _, v := range m.Elements() {
+		if v.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
 type flowDetailsResult struct {
-	Name   types.String
-	Blocks types.Map
+	// ProjectID, Enabled and Managed are null when the API doesn't report them.
+	ProjectID types.String
+	Name      types.String
+	Enabled   types.Bool
+	Managed   types.Bool
+	Blocks    types.Map
 }
 
-func (r *FlowResource) getFlowDetails(ctx context.Context, flowID string) (*flowDetailsResult, error) {
-	getFlowResp, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](*r.providerData, "/provider/flows/get", GetFlowRequest{
+// applyTo sets the flow details on the model, keeping its values of the details the API didn't report.
+func (d *flowDetailsResult) applyTo(data *FlowResourceModel) {
+	if !d.ProjectID.IsNull() {
+		data.ProjectId = d.ProjectID
+	}
+	data.Name = d.Name
+	data.Blocks = d.Blocks
+	if !d.Enabled.IsNull() {
+		data.Enabled = d.Enabled
+	}
+	if !d.Managed.IsNull() {
+		data.MarkAsManaged = d.Managed
+	}
+}
+
+var flowBlockDetailsAttrTypes = map[string]attr.Type{
+	"id":                  types.StringType,
+	"type":                types.StringType,
+	"app_installation_id": types.StringType,
+	"app_key":             types.StringType,
+	"public_url":          types.StringType,
+}
+
+func getFlowDetails(ctx context.Context, providerData FlowsProviderConfiguredData, flowID string) (*flowDetailsResult, error) {
+	getFlowResp, err := CallFlowsAPI[GetFlowRequest, GetFlowResponse](providerData, "/provider/flows/get", GetFlowRequest{
 		FlowID: flowID,
 	})
 	if err != nil {
@@ -453,27 +1697,26 @@ func (r *FlowResource) getFlowDetails(ctx context.Context, flowID string) (*flow
 	// Convert blocks to Terraform types
 	blockElements := make(map[string]attr.Value)
 	for name, block := range getFlowResp.Blocks {
-		blockAttrs := map[string]attr.Value{
-			"id": types.StringValue(block.ID),
-		}
-		blockObj, _ := types.ObjectValue(
-			map[string]attr.Type{
-				"id": types.StringType,
-			},
-			blockAttrs,
-		)
-		blockElements[name] = blockObj
+		blockElements[name] = flowBlockDetailsValue(block)
 	}
 
-	blockElementType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"id": types.StringType,
-		},
-	}
-	blocks, _ := types.MapValue(blockElementType, blockElements)
+	blocks, _ := types.MapValue(types.ObjectType{AttrTypes: flowBlockDetailsAttrTypes}, blockElements)
 
 	return &flowDetailsResult{
-		Name:   types.StringValue(getFlowResp.Name),
-		Blocks: blocks,
+		ProjectID: types.StringPointerValue(getFlowResp.ProjectID),
+		Name:      types.StringValue(getFlowResp.Name),
+		Enabled:   types.BoolPointerValue(getFlowResp.Enabled),
+		Managed:   types.BoolPointerValue(getFlowResp.Managed),
+		Blocks:    blocks,
 	}, nil
 }
+
+func flowBlockDetailsValue(block GetFlowBlock) types.Object {
+	return types.ObjectValueMust(flowBlockDetailsAttrTypes, map[string]attr.Value{
+		"id":                  types.StringValue(block.ID),
+		"type":                types.StringValue(block.Type),
+		"app_installation_id": types.StringPointerValue(block.AppInstallationID),
+		"app_key":             types.StringPointerValue(block.AppKey),
+		"public_url":          types.StringPointerValue(block.PublicURL),
+	})
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &FlowRevisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &FlowRevisionsDataSource{}
+)
+
+type FlowRevisionsDataSource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+func (ds *FlowRevisionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func NewFlowRevisionsDataSource() datasource.DataSource {
+	return &FlowRevisionsDataSource{}
+}
+
+type FlowRevisionsDataSourceModel struct {
+	FlowID    types.String `tfsdk:"flow_id"`
+	Revisions types.List   `tfsdk:"revisions"`
+}
+
+var flowRevisionAttrTypes = map[string]attr.Type{
+	"revision":   types.Int64Type,
+	"created_at": types.StringType,
+	"definition": types.StringType,
+}
+
+func (ds *FlowRevisionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_revisions"
+}
+
+func (ds *FlowRevisionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source for listing the revisions of a flow, recorded by the `flows_flow` resource whenever it changes the flow's definition. A flow can be rolled back to one of them with `rollback_to_revision`.",
+		Attributes: map[string]schema.Attribute{
+			"flow_id": schema.StringAttribute{
+				Description: "ID of the flow to list the revisions of.",
+				Required:    true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				Description: "Revisions of the flow, oldest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"revision": schema.Int64Attribute{
+							Description: "Number of the revision.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Time the revision was recorded at, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"definition": schema.StringAttribute{
+							Description: "YAML definition of the flow at this revision, as exported from the Flows API.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type ListFlowRevisionsRequest struct {
+	FlowID string `json:"flowId"`
+}
+
+type ListFlowRevisionsResponse struct {
+	Revisions []ListFlowRevisionsItem `json:"revisions"`
+}
+
+type ListFlowRevisionsItem struct {
+	Revision   int64  `json:"revision"`
+	CreatedAt  string `json:"createdAt"`
+	Definition string `json:"definition"`
+}
+
+func (ds *FlowRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FlowRevisionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listRevisionsResp, err := CallFlowsAPI[ListFlowRevisionsRequest, ListFlowRevisionsResponse](*ds.providerData, "/provider/flows/list_revisions", ListFlowRevisionsRequest{
+		FlowID: data.FlowID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to list flow revisions, got error: "+err.Error())
+		return
+	}
+
+	revisions := append([]ListFlowRevisionsItem(nil), listRevisionsResp.Revisions...)
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+
+	elements := make([]attr.Value, 0, len(revisions))
+	for _, revision := range revisions {
+		elements = append(elements, types.ObjectValueMust(flowRevisionAttrTypes, map[string]attr.Value{
+			"revision":   types.Int64Value(revision.Revision),
+			"created_at": types.StringValue(revision.CreatedAt),
+			"definition": types.StringValue(revision.Definition),
+		}))
+	}
+	data.Revisions = types.ListValueMust(types.ObjectType{AttrTypes: flowRevisionAttrTypes}, elements)
+
+	// Write state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FlowRunResource{}
+
+const (
+	triggerFlowRunPath = "/provider/flows/trigger_run"
+	getFlowRunPath     = "/provider/flows/get_run"
+)
+
+const defaultFlowRunTimeout = 5 * time.Minute
+
+func NewFlowRunResource() resource.Resource {
+	return &FlowRunResource{}
+}
+
+type FlowRunResource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+type FlowRunResourceModel struct {
+	FlowID            types.String   `tfsdk:"flow_id"`
+	BlockName         types.String   `tfsdk:"block_name"`
+	Input             types.String   `tfsdk:"input"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	ID                types.String   `tfsdk:"id"`
+	Status            types.String   `tfsdk:"status"`
+	Duration          types.Float64  `tfsdk:"duration"`
+	Output            types.String   `tfsdk:"output"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *FlowRunResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_run"
+}
+
+func (r *FlowRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Triggers a run of a flow, or of a specific block in it, and optionally waits for it to finish.
+
+This is useful for smoke testing a flow right after deploying it. Any change to the arguments triggers a new run, so use "triggers" to re-run the flow whenever e.g. its definition changes.`,
+		Attributes: map[string]schema.Attribute{
+			"flow_id": schema.StringAttribute{
+				Description: "ID of the flow to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"block_name": schema.StringAttribute{
+				Description: "Name of the block to trigger. If not set, the flow is triggered as a whole.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"input": schema.StringAttribute{
+				MarkdownDescription: "Input payload of the run as JSON, usually built with `jsonencode()`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "Whether to wait for the run to finish, failing if the run fails.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which trigger a new run when changed, e.g. `{ definition = flows_flow.example.definition_sha256 }`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the run.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the run.",
+				Computed:    true,
+			},
+			"duration": schema.Float64Attribute{
+				Description: "Duration of the run in seconds, once it has finished.",
+				Computed:    true,
+			},
+			"output": schema.StringAttribute{
+				Description: "Output of the run as JSON, once it has finished.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: `How long to wait for the run to finish when "wait_for_completion" is true. Defaults to 5 minutes.`,
+			}),
+		},
+	}
+}
+
+func (r *FlowRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+type TriggerFlowRunRequest struct {
+	FlowID    string          `json:"flowId"`
+	BlockName string          `json:"blockName,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+}
+
+type TriggerFlowRunResponse struct {
+	RunID string `json:"runId"`
+}
+
+type GetFlowRunRequest struct {
+	RunID string `json:"runId"`
+}
+
+type GetFlowRunResponse struct {
+	Status     string          `json:"status"`
+	DurationMs *int64          `json:"durationMs,omitempty"`
+	Output     json.RawMessage `json:"output,omitempty"`
+}
+
+func (r *FlowRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FlowRunResourceModel
+
+	// Read Terraform plan data into the model.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultFlowRunTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input json.RawMessage
+	if !data.Input.IsNull() {
+		if !json.Valid([]byte(data.Input.ValueString())) {
+			resp.Diagnostics.AddAttributeError(path.Root("input"), "Invalid Input", "The input of the run must be valid JSON.")
+			return
+		}
+		input = json.RawMessage(data.Input.ValueString())
+	}
+
+	triggerRes, err := CallFlowsAPI[TriggerFlowRunRequest, TriggerFlowRunResponse](*r.providerData, triggerFlowRunPath, TriggerFlowRunRequest{
+		FlowID:    data.FlowID.ValueString(),
+		BlockName: data.BlockName.ValueString(),
+		Input:     input,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to trigger flow run, got error: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(triggerRes.RunID)
+
+	run, err := CallFlowsAPI[GetFlowRunRequest, GetFlowRunResponse](*r.providerData, getFlowRunPath, GetFlowRunRequest{
+		RunID: triggerRes.RunID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to read flow run, got error: "+err.Error())
+		return
+	}
+	setFlowRunResult(&data, run)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.WaitForCompletion.ValueBool() {
+		return
+	}
+
+	run = r.waitForCompletion(ctx, triggerRes.RunID, createTimeout, resp)
+	if run == nil {
+		return
+	}
+	setFlowRunResult(&data, run)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForCompletion polls the run until it has finished, returning its last known state,
+// or nil if it could not be read.
+func (r *FlowRunResource) waitForCompletion(ctx context.Context, runID string, timeout time.Duration, resp *resource.CreateResponse) *GetFlowRunResponse {
+	var run *GetFlowRunResponse
+
+	statusWaiter{
+		Kind:      "Flow Run",
+		Subject:   fmt.Sprintf("Flow run %s", runID),
+		LogFields: map[string]any{"run_id": runID},
+		Fetch: func() (string, error) {
+			current, err := CallFlowsAPI[GetFlowRunRequest, GetFlowRunResponse](*r.providerData, getFlowRunPath, GetFlowRunRequest{
+				RunID: runID,
+			})
+			if err != nil {
+				return "", err
+			}
+			run = current
+			return run.Status, nil
+		},
+		Target:  []string{"succeeded"},
+		Pending: []string{"queued", "running"},
+		Failure: []string{"failed", "cancelled"},
+		Timeout: timeout,
+	}.Wait(ctx, &resp.Diagnostics)
+
+	return run
+}
+
+func setFlowRunResult(data *FlowRunResourceModel, run *GetFlowRunResponse) {
+	data.Status = types.StringValue(run.Status)
+
+	data.Duration = types.Float64Null()
+	if run.DurationMs != nil {
+		data.Duration = types.Float64Value(float64(*run.DurationMs) / 1000)
+	}
+
+	data.Output = rawJSONValue(run.Output)
+}
+
+func (r *FlowRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FlowRunResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	run, err := CallFlowsAPI[GetFlowRunRequest, GetFlowRunResponse](*r.providerData, getFlowRunPath, GetFlowRunRequest{
+		RunID: data.ID.ValueString(),
+	})
+	if err != nil {
+		if err.Error() == "not found" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", "Unable to read flow run, got error: "+err.Error())
+		return
+	}
+	setFlowRunResult(&data, run)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FlowRunResourceModel
+
+	// Every argument other than the timeouts requires a new run, so only those can change here.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FlowRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to do on delete, the run stays in the flow's history.
+}
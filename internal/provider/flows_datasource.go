@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &FlowsDataSource{}
+	_ datasource.DataSourceWithConfigure = &FlowsDataSource{}
+)
+
+const listFlowsPath = "/provider/flows/list"
+
+type FlowsDataSource struct {
+	providerData *FlowsProviderConfiguredData
+}
+
+func (ds *FlowsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ds.providerData = req.ProviderData.(*FlowsProviderConfiguredData)
+}
+
+func NewFlowsDataSource() datasource.DataSource {
+	return &FlowsDataSource{}
+}
+
+type FlowsDataSourceModel struct {
+	ProjectID  types.String `tfsdk:"project_id"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+	Flows      types.List   `tfsdk:"flows"`
+}
+
+var flowsDataSourceFlowAttrTypes = map[string]attr.Type{
+	"id":      types.StringType,
+	"name":    types.StringType,
+	"enabled": types.BoolType,
+}
+
+func (ds *FlowsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flows"
+}
+
+func (ds *FlowsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source for listing the flows in a project, optionally filtered by name.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Description: "ID of the project to list the flows of.",
+				Required:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list flows whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list flows whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"flows": schema.ListNestedAttribute{
+				Description: "Flows in the project matching the filters, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the flow.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the flow.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the flow is running.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type ListFlowsRequest struct {
+	ProjectID string `json:"projectId"`
+}
+
+type ListFlowsResponse struct {
+	Flows []ListFlowsItem `json:"flows"`
+}
+
+type ListFlowsItem struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (ds *FlowsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FlowsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	listFlowsResp, err := CallFlowsAPI[ListFlowsRequest, ListFlowsResponse](*ds.providerData, listFlowsPath, ListFlowsRequest{
+		ProjectID: data.ProjectID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Unable to list flows, got error: "+err.Error())
+		return
+	}
+
+	flows := make([]attr.Value, 0, len(listFlowsResp.Flows))
+	for _, flow := range sortedFlows(listFlowsResp.Flows) {
+		if !strings.HasPrefix(flow.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(flow.Name) {
+			continue
+		}
+
+		flows = append(flows, types.ObjectValueMust(flowsDataSourceFlowAttrTypes, map[string]attr.Value{
+			"id":      types.StringValue(flow.ID),
+			"name":    types.StringValue(flow.Name),
+			"enabled": types.BoolValue(flow.Enabled),
+		}))
+	}
+	data.Flows = types.ListValueMust(types.ObjectType{AttrTypes: flowsDataSourceFlowAttrTypes}, flows)
+
+	// Write state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func sortedFlows(flows []ListFlowsItem) []ListFlowsItem {
+	sorted := append([]ListFlowsItem(nil), flows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
@@ -93,6 +93,9 @@ func (p *FlowsProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *FlowsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewFlowResource,
+		NewFlowBlockResource,
+		NewFlowPromotionResource,
+		NewFlowRunResource,
 		NewAppInstallationResource,
 		NewAppInstallationConfirmationResource,
 		NewAppInstallationWaitForReadyResource,
@@ -108,6 +111,10 @@ func (p *FlowsProvider) Resources(ctx context.Context) []func() resource.Resourc
 func (p *FlowsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAppVersionDataSource,
+		NewFlowBlockDataSource,
+		NewFlowDataSource,
+		NewFlowRevisionsDataSource,
+		NewFlowsDataSource,
 	}
 }
 
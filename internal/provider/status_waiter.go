@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultPollTimeout  = 5 * time.Minute
+	pollInitialInterval = 2 * time.Second
+	pollMaxInterval     = 15 * time.Second
+)
+
+// statusDeleted is the status reported by fetchers of objects which no longer exist, so that deletion can be waited for like any other status.
+const statusDeleted = "deleted"
+
+// Lifecycle statuses shared by app installations and entities.
+var (
+	lifecycleReadyStatuses   = []string{"ready"}
+	lifecyclePendingStatuses = []string{"draft", "in_progress"}
+	lifecycleFailureStatuses = []string{"failed", "drifted", "draining_failed", "draining", "drained"}
+	drainingStatuses         = []string{"draining", "drained"}
+	// deletionFailureStatuses are the statuses of objects which are no longer being deleted.
+	deletionFailureStatuses = []string{"ready", "failed", "drifted", "draining_failed", "draft", "in_progress"}
+	// The entities of a deleted flow's blocks can still be in any of their usual statuses until they start draining.
+	flowDeletionPendingStatuses = []string{"ready", "draft", "in_progress", "draining", "drained"}
+	flowDeletionFailureStatuses = []string{"failed", "drifted", "draining_failed"}
+)
+
+// statusWaiter polls the status of an object until it reaches one of the target statuses,
+// backing off exponentially between attempts.
+type statusWaiter struct {
+	// Kind names the object in diagnostic titles, e.g. "App Installation".
+	Kind string
+	// Subject identifies the object in diagnostic details, e.g. `App Installation "abc"`.
+	Subject string
+	// LogFields are added to the progress logs.
+	LogFields map[string]any
+
+	// Fetch returns the current status of the object.
+	Fetch func() (string, error)
+
+	// Target statuses end the wait successfully, Pending ones continue it and Failure ones end it with an error.
+	// Any other status is reported as unknown.
+	Target  []string
+	Pending []string
+	Failure []string
+
+	Timeout time.Duration
+}
+
+// Wait polls the status until it settles or the timeout is reached, reporting failures in dg.
+// It returns the last status, and whether polling stopped on a settled (target, failure or unknown) status
+// rather than on a client error or the timeout.
+func (w statusWaiter) Wait(ctx context.Context, dg *diag.Diagnostics) (string, bool) {
+	start := time.Now()
+	deadline := start.Add(w.Timeout)
+	interval := pollInitialInterval
+
+	var status string
+	for attempt := 1; ; attempt++ {
+		current, err := w.Fetch()
+		if err != nil {
+			dg.AddError("Client Error", fmt.Sprintf("Unable to read status of %s, got error: %s", w.Subject, err))
+			return status, false
+		}
+
+		fields := maps.Clone(w.LogFields)
+		if fields == nil {
+			fields = make(map[string]any)
+		}
+		fields["status"] = current
+		fields["attempt"] = attempt
+		fields["elapsed"] = time.Since(start).Round(time.Second).String()
+
+		if current != status {
+			tflog.Info(ctx, w.Kind+" status changed", fields)
+		} else {
+			tflog.Debug(ctx, w.Kind+" status", fields)
+		}
+		status = current
+
+		switch {
+		case slices.Contains(w.Target, status):
+			// Success case
+			return status, true
+		case slices.Contains(w.Failure, status):
+			// Terminal failure states
+			dg.AddError(
+				w.Kind+" Failed",
+				fmt.Sprintf("%s reached status %q instead of %s", w.Subject, status, quotedStatuses(w.Target)),
+			)
+			return status, true
+		case slices.Contains(w.Pending, status):
+			// Transitional states, continue polling
+		default:
+			// Unknown status
+			dg.AddError(
+				"Unknown "+w.Kind+" Status",
+				fmt.Sprintf("%s has unknown status %q", w.Subject, status),
+			)
+			return status, true
+		}
+
+		wait := min(interval, remaining(deadline))
+		if wait <= 0 {
+			// Timeout reached, after a last fetch at the deadline
+			dg.AddError(
+				w.Kind+" Timeout",
+				fmt.Sprintf("%s did not reach %s within %s, last status was %q", w.Subject, quotedStatuses(w.Target), w.Timeout, status),
+			)
+			return status, false
+		}
+
+		select {
+		case <-ctx.Done():
+			dg.AddError(w.Kind+" Cancelled", fmt.Sprintf("Stopped waiting for %s: %s", w.Subject, ctx.Err()))
+			return status, false
+		case <-time.After(wait):
+		}
+
+		interval = min(interval*2, pollMaxInterval)
+	}
+}
+
+// remaining returns the time left until the deadline, for waiting for several objects within one timeout.
+func remaining(deadline time.Time) time.Duration {
+	return max(time.Until(deadline), 0)
+}
+
+func quotedStatuses(statuses []string) string {
+	quoted := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		quoted = append(quoted, fmt.Sprintf("%q", status))
+	}
+	return strings.Join(quoted, " or ")
+}